@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: github.com/skipor/gmg/examples/2_target_interface_select/sub.Baz
+// Method set hash: Baz=47aa9a1c4dafdbfc
+// Command: gmg --src github.com/skipor/gmg/examples/2_target_interface_select/sub --dst ./baz.go --pkg example_mocks Baz
+// Body hash: e4f370498c952d07
 
 package example_mocks
 
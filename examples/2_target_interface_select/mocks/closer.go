@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: io.Closer
+// Method set hash: Closer=fe98a625b81916a3
+// Command: gmg --src io --dst ./closer.go --pkg example_mocks Closer
+// Body hash: c0c89c982e123c93
 
 package example_mocks
 
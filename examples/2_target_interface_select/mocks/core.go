@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: go.uber.org/zap/zapcore.Core
+// Method set hash: Core=a0ed5cd4601d6e4b
+// Command: gmg --src go.uber.org/zap/zapcore --dst ./core.go --pkg example_mocks Core
+// Body hash: 0bb34dff9a3f4a85
 
 package example_mocks
 
@@ -7,7 +10,6 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
-
 	zapcore "go.uber.org/zap/zapcore"
 )
 
@@ -68,7 +70,7 @@ func (m_ *MockCore) Write(arg zapcore.Entry, arg2 []zapcore.Field) error {
 type MockCoreMockRecorder MockCore
 
 // Check(zapcore.Entry, *zapcore.CheckedEntry) *zapcore.CheckedEntry
-func (r_ *MockCoreMockRecorder) Check(arg interface{}, arg2 interface{}) MockCoreCheckCall {
+func (r_ *MockCoreMockRecorder) Check(arg any, arg2 any) MockCoreCheckCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Check", reflect.TypeOf((*MockCore)(nil).Check), arg, arg2)
 	return MockCoreCheckCall{call}
@@ -96,7 +98,7 @@ func (c_ MockCoreCheckCall) Return(res0 *zapcore.CheckedEntry) MockCoreCheckCall
 }
 
 // Enabled(zapcore.Level) bool
-func (r_ *MockCoreMockRecorder) Enabled(arg interface{}) MockCoreEnabledCall {
+func (r_ *MockCoreMockRecorder) Enabled(arg any) MockCoreEnabledCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Enabled", reflect.TypeOf((*MockCore)(nil).Enabled), arg)
 	return MockCoreEnabledCall{call}
@@ -152,7 +154,7 @@ func (c_ MockCoreSyncCall) Return(res0 error) MockCoreSyncCall {
 }
 
 // With([]zapcore.Field) zapcore.Core
-func (r_ *MockCoreMockRecorder) With(arg interface{}) MockCoreWithCall {
+func (r_ *MockCoreMockRecorder) With(arg any) MockCoreWithCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "With", reflect.TypeOf((*MockCore)(nil).With), arg)
 	return MockCoreWithCall{call}
@@ -180,7 +182,7 @@ func (c_ MockCoreWithCall) Return(res0 zapcore.Core) MockCoreWithCall {
 }
 
 // Write(zapcore.Entry, []zapcore.Field) error
-func (r_ *MockCoreMockRecorder) Write(arg interface{}, arg2 interface{}) MockCoreWriteCall {
+func (r_ *MockCoreMockRecorder) Write(arg any, arg2 any) MockCoreWriteCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Write", reflect.TypeOf((*MockCore)(nil).Write), arg, arg2)
 	return MockCoreWriteCall{call}
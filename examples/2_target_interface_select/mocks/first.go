@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: github.com/skipor/gmg/examples/2_target_interface_select.First
+// Method set hash: First=4e6e004cf99e102d
+// Command: gmg --src github.com/skipor/gmg/examples/2_target_interface_select --dst ./first.go --pkg example_mocks First
+// Body hash: 5edbd3e9590b6805
 
 package example_mocks
 
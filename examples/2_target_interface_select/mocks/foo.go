@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: github.com/skipor/gmg/examples/2_target_interface_select.Foo
+// Method set hash: Foo=56668fe2bec8cc3b
+// Command: gmg --src github.com/skipor/gmg/examples/2_target_interface_select --dst ./foo.go --pkg example_mocks Foo
+// Body hash: f8bbfc123b05f568
 
 package example_mocks
 
@@ -34,7 +37,7 @@ func (m_ *MockFoo) Bar(s string) error {
 type MockFooMockRecorder MockFoo
 
 // Bar(s string) error
-func (r_ *MockFooMockRecorder) Bar(s interface{}) MockFooBarCall {
+func (r_ *MockFooMockRecorder) Bar(s any) MockFooBarCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Bar", reflect.TypeOf((*MockFoo)(nil).Bar), s)
 	return MockFooBarCall{call}
@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: io.Reader
+// Method set hash: Reader=27a5beff5a73a501
+// Command: gmg --src io --dst ./reader.go --pkg example_mocks Reader
+// Body hash: 9ad1c2f117d8a5f7
 
 package example_mocks
 
@@ -35,7 +38,7 @@ func (m_ *MockReader) Read(p []byte) (n int, err error) {
 type MockReaderMockRecorder MockReader
 
 // Read(p []byte) (n int, err error)
-func (r_ *MockReaderMockRecorder) Read(p interface{}) MockReaderReadCall {
+func (r_ *MockReaderMockRecorder) Read(p any) MockReaderReadCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Read", reflect.TypeOf((*MockReader)(nil).Read), p)
 	return MockReaderReadCall{call}
@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: github.com/skipor/gmg/examples/2_target_interface_select.Second
+// Method set hash: Second=a801bbb23805a494
+// Command: gmg --src github.com/skipor/gmg/examples/2_target_interface_select --dst ./second.go --pkg example_mocks Second
+// Body hash: 65692cad1012469b
 
 package example_mocks
 
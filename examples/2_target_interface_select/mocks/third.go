@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: github.com/skipor/gmg/examples/2_target_interface_select.Third
+// Method set hash: Third=7d1c50ea9c14a85c
+// Command: gmg --src github.com/skipor/gmg/examples/2_target_interface_select --dst ./third.go --pkg example_mocks Third
+// Body hash: aa203e24174bcf73
 
 package example_mocks
 
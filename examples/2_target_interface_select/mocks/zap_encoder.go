@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: github.com/skipor/gmg/examples/2_target_interface_select.ZapEncoder
+// Method set hash: ZapEncoder=fb5107b4bb5e8251
+// Command: gmg --src github.com/skipor/gmg/examples/2_target_interface_select --dst ./zap_encoder.go --pkg example_mocks ZapEncoder
+// Body hash: ade9486ae895bc97
 
 package example_mocks
 
@@ -224,7 +227,7 @@ func (m_ *MockZapEncoder) OpenNamespace(key string) {
 type MockZapEncoderMockRecorder MockZapEncoder
 
 // AddArray(key string, marshaler zapcore.ArrayMarshaler) error
-func (r_ *MockZapEncoderMockRecorder) AddArray(key interface{}, marshaler interface{}) MockZapEncoderAddArrayCall {
+func (r_ *MockZapEncoderMockRecorder) AddArray(key any, marshaler any) MockZapEncoderAddArrayCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddArray", reflect.TypeOf((*MockZapEncoder)(nil).AddArray), key, marshaler)
 	return MockZapEncoderAddArrayCall{call}
@@ -252,7 +255,7 @@ func (c_ MockZapEncoderAddArrayCall) Return(res0 error) MockZapEncoderAddArrayCa
 }
 
 // AddBinary(key string, value []byte)
-func (r_ *MockZapEncoderMockRecorder) AddBinary(key interface{}, value interface{}) MockZapEncoderAddBinaryCall {
+func (r_ *MockZapEncoderMockRecorder) AddBinary(key any, value any) MockZapEncoderAddBinaryCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddBinary", reflect.TypeOf((*MockZapEncoder)(nil).AddBinary), key, value)
 	return MockZapEncoderAddBinaryCall{call}
@@ -274,7 +277,7 @@ func (c_ MockZapEncoderAddBinaryCall) Do(f func(key string, value []byte)) MockZ
 }
 
 // AddBool(key string, value bool)
-func (r_ *MockZapEncoderMockRecorder) AddBool(key interface{}, value interface{}) MockZapEncoderAddBoolCall {
+func (r_ *MockZapEncoderMockRecorder) AddBool(key any, value any) MockZapEncoderAddBoolCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddBool", reflect.TypeOf((*MockZapEncoder)(nil).AddBool), key, value)
 	return MockZapEncoderAddBoolCall{call}
@@ -296,7 +299,7 @@ func (c_ MockZapEncoderAddBoolCall) Do(f func(key string, value bool)) MockZapEn
 }
 
 // AddByteString(key string, value []byte)
-func (r_ *MockZapEncoderMockRecorder) AddByteString(key interface{}, value interface{}) MockZapEncoderAddByteStringCall {
+func (r_ *MockZapEncoderMockRecorder) AddByteString(key any, value any) MockZapEncoderAddByteStringCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddByteString", reflect.TypeOf((*MockZapEncoder)(nil).AddByteString), key, value)
 	return MockZapEncoderAddByteStringCall{call}
@@ -318,7 +321,7 @@ func (c_ MockZapEncoderAddByteStringCall) Do(f func(key string, value []byte)) M
 }
 
 // AddComplex128(key string, value complex128)
-func (r_ *MockZapEncoderMockRecorder) AddComplex128(key interface{}, value interface{}) MockZapEncoderAddComplex128Call {
+func (r_ *MockZapEncoderMockRecorder) AddComplex128(key any, value any) MockZapEncoderAddComplex128Call {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddComplex128", reflect.TypeOf((*MockZapEncoder)(nil).AddComplex128), key, value)
 	return MockZapEncoderAddComplex128Call{call}
@@ -340,7 +343,7 @@ func (c_ MockZapEncoderAddComplex128Call) Do(f func(key string, value complex128
 }
 
 // AddComplex64(key string, value complex64)
-func (r_ *MockZapEncoderMockRecorder) AddComplex64(key interface{}, value interface{}) MockZapEncoderAddComplex64Call {
+func (r_ *MockZapEncoderMockRecorder) AddComplex64(key any, value any) MockZapEncoderAddComplex64Call {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddComplex64", reflect.TypeOf((*MockZapEncoder)(nil).AddComplex64), key, value)
 	return MockZapEncoderAddComplex64Call{call}
@@ -362,7 +365,7 @@ func (c_ MockZapEncoderAddComplex64Call) Do(f func(key string, value complex64))
 }
 
 // AddDuration(key string, value time.Duration)
-func (r_ *MockZapEncoderMockRecorder) AddDuration(key interface{}, value interface{}) MockZapEncoderAddDurationCall {
+func (r_ *MockZapEncoderMockRecorder) AddDuration(key any, value any) MockZapEncoderAddDurationCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddDuration", reflect.TypeOf((*MockZapEncoder)(nil).AddDuration), key, value)
 	return MockZapEncoderAddDurationCall{call}
@@ -384,7 +387,7 @@ func (c_ MockZapEncoderAddDurationCall) Do(f func(key string, value time.Duratio
 }
 
 // AddFloat32(key string, value float32)
-func (r_ *MockZapEncoderMockRecorder) AddFloat32(key interface{}, value interface{}) MockZapEncoderAddFloat32Call {
+func (r_ *MockZapEncoderMockRecorder) AddFloat32(key any, value any) MockZapEncoderAddFloat32Call {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddFloat32", reflect.TypeOf((*MockZapEncoder)(nil).AddFloat32), key, value)
 	return MockZapEncoderAddFloat32Call{call}
@@ -406,7 +409,7 @@ func (c_ MockZapEncoderAddFloat32Call) Do(f func(key string, value float32)) Moc
 }
 
 // AddFloat64(key string, value float64)
-func (r_ *MockZapEncoderMockRecorder) AddFloat64(key interface{}, value interface{}) MockZapEncoderAddFloat64Call {
+func (r_ *MockZapEncoderMockRecorder) AddFloat64(key any, value any) MockZapEncoderAddFloat64Call {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddFloat64", reflect.TypeOf((*MockZapEncoder)(nil).AddFloat64), key, value)
 	return MockZapEncoderAddFloat64Call{call}
@@ -428,7 +431,7 @@ func (c_ MockZapEncoderAddFloat64Call) Do(f func(key string, value float64)) Moc
 }
 
 // AddInt(key string, value int)
-func (r_ *MockZapEncoderMockRecorder) AddInt(key interface{}, value interface{}) MockZapEncoderAddIntCall {
+func (r_ *MockZapEncoderMockRecorder) AddInt(key any, value any) MockZapEncoderAddIntCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddInt", reflect.TypeOf((*MockZapEncoder)(nil).AddInt), key, value)
 	return MockZapEncoderAddIntCall{call}
@@ -450,7 +453,7 @@ func (c_ MockZapEncoderAddIntCall) Do(f func(key string, value int)) MockZapEnco
 }
 
 // AddInt16(key string, value int16)
-func (r_ *MockZapEncoderMockRecorder) AddInt16(key interface{}, value interface{}) MockZapEncoderAddInt16Call {
+func (r_ *MockZapEncoderMockRecorder) AddInt16(key any, value any) MockZapEncoderAddInt16Call {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddInt16", reflect.TypeOf((*MockZapEncoder)(nil).AddInt16), key, value)
 	return MockZapEncoderAddInt16Call{call}
@@ -472,7 +475,7 @@ func (c_ MockZapEncoderAddInt16Call) Do(f func(key string, value int16)) MockZap
 }
 
 // AddInt32(key string, value int32)
-func (r_ *MockZapEncoderMockRecorder) AddInt32(key interface{}, value interface{}) MockZapEncoderAddInt32Call {
+func (r_ *MockZapEncoderMockRecorder) AddInt32(key any, value any) MockZapEncoderAddInt32Call {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddInt32", reflect.TypeOf((*MockZapEncoder)(nil).AddInt32), key, value)
 	return MockZapEncoderAddInt32Call{call}
@@ -494,7 +497,7 @@ func (c_ MockZapEncoderAddInt32Call) Do(f func(key string, value int32)) MockZap
 }
 
 // AddInt64(key string, value int64)
-func (r_ *MockZapEncoderMockRecorder) AddInt64(key interface{}, value interface{}) MockZapEncoderAddInt64Call {
+func (r_ *MockZapEncoderMockRecorder) AddInt64(key any, value any) MockZapEncoderAddInt64Call {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddInt64", reflect.TypeOf((*MockZapEncoder)(nil).AddInt64), key, value)
 	return MockZapEncoderAddInt64Call{call}
@@ -516,7 +519,7 @@ func (c_ MockZapEncoderAddInt64Call) Do(f func(key string, value int64)) MockZap
 }
 
 // AddInt8(key string, value int8)
-func (r_ *MockZapEncoderMockRecorder) AddInt8(key interface{}, value interface{}) MockZapEncoderAddInt8Call {
+func (r_ *MockZapEncoderMockRecorder) AddInt8(key any, value any) MockZapEncoderAddInt8Call {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddInt8", reflect.TypeOf((*MockZapEncoder)(nil).AddInt8), key, value)
 	return MockZapEncoderAddInt8Call{call}
@@ -538,7 +541,7 @@ func (c_ MockZapEncoderAddInt8Call) Do(f func(key string, value int8)) MockZapEn
 }
 
 // AddObject(key string, marshaler zapcore.ObjectMarshaler) error
-func (r_ *MockZapEncoderMockRecorder) AddObject(key interface{}, marshaler interface{}) MockZapEncoderAddObjectCall {
+func (r_ *MockZapEncoderMockRecorder) AddObject(key any, marshaler any) MockZapEncoderAddObjectCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddObject", reflect.TypeOf((*MockZapEncoder)(nil).AddObject), key, marshaler)
 	return MockZapEncoderAddObjectCall{call}
@@ -566,7 +569,7 @@ func (c_ MockZapEncoderAddObjectCall) Return(res0 error) MockZapEncoderAddObject
 }
 
 // AddReflected(key string, value interface{}) error
-func (r_ *MockZapEncoderMockRecorder) AddReflected(key interface{}, value interface{}) MockZapEncoderAddReflectedCall {
+func (r_ *MockZapEncoderMockRecorder) AddReflected(key any, value any) MockZapEncoderAddReflectedCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddReflected", reflect.TypeOf((*MockZapEncoder)(nil).AddReflected), key, value)
 	return MockZapEncoderAddReflectedCall{call}
@@ -594,7 +597,7 @@ func (c_ MockZapEncoderAddReflectedCall) Return(res0 error) MockZapEncoderAddRef
 }
 
 // AddString(key string, value string)
-func (r_ *MockZapEncoderMockRecorder) AddString(key interface{}, value interface{}) MockZapEncoderAddStringCall {
+func (r_ *MockZapEncoderMockRecorder) AddString(key any, value any) MockZapEncoderAddStringCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddString", reflect.TypeOf((*MockZapEncoder)(nil).AddString), key, value)
 	return MockZapEncoderAddStringCall{call}
@@ -616,7 +619,7 @@ func (c_ MockZapEncoderAddStringCall) Do(f func(key string, value string)) MockZ
 }
 
 // AddTime(key string, value time.Time)
-func (r_ *MockZapEncoderMockRecorder) AddTime(key interface{}, value interface{}) MockZapEncoderAddTimeCall {
+func (r_ *MockZapEncoderMockRecorder) AddTime(key any, value any) MockZapEncoderAddTimeCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddTime", reflect.TypeOf((*MockZapEncoder)(nil).AddTime), key, value)
 	return MockZapEncoderAddTimeCall{call}
@@ -638,7 +641,7 @@ func (c_ MockZapEncoderAddTimeCall) Do(f func(key string, value time.Time)) Mock
 }
 
 // AddUint(key string, value uint)
-func (r_ *MockZapEncoderMockRecorder) AddUint(key interface{}, value interface{}) MockZapEncoderAddUintCall {
+func (r_ *MockZapEncoderMockRecorder) AddUint(key any, value any) MockZapEncoderAddUintCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddUint", reflect.TypeOf((*MockZapEncoder)(nil).AddUint), key, value)
 	return MockZapEncoderAddUintCall{call}
@@ -660,7 +663,7 @@ func (c_ MockZapEncoderAddUintCall) Do(f func(key string, value uint)) MockZapEn
 }
 
 // AddUint16(key string, value uint16)
-func (r_ *MockZapEncoderMockRecorder) AddUint16(key interface{}, value interface{}) MockZapEncoderAddUint16Call {
+func (r_ *MockZapEncoderMockRecorder) AddUint16(key any, value any) MockZapEncoderAddUint16Call {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddUint16", reflect.TypeOf((*MockZapEncoder)(nil).AddUint16), key, value)
 	return MockZapEncoderAddUint16Call{call}
@@ -682,7 +685,7 @@ func (c_ MockZapEncoderAddUint16Call) Do(f func(key string, value uint16)) MockZ
 }
 
 // AddUint32(key string, value uint32)
-func (r_ *MockZapEncoderMockRecorder) AddUint32(key interface{}, value interface{}) MockZapEncoderAddUint32Call {
+func (r_ *MockZapEncoderMockRecorder) AddUint32(key any, value any) MockZapEncoderAddUint32Call {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddUint32", reflect.TypeOf((*MockZapEncoder)(nil).AddUint32), key, value)
 	return MockZapEncoderAddUint32Call{call}
@@ -704,7 +707,7 @@ func (c_ MockZapEncoderAddUint32Call) Do(f func(key string, value uint32)) MockZ
 }
 
 // AddUint64(key string, value uint64)
-func (r_ *MockZapEncoderMockRecorder) AddUint64(key interface{}, value interface{}) MockZapEncoderAddUint64Call {
+func (r_ *MockZapEncoderMockRecorder) AddUint64(key any, value any) MockZapEncoderAddUint64Call {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddUint64", reflect.TypeOf((*MockZapEncoder)(nil).AddUint64), key, value)
 	return MockZapEncoderAddUint64Call{call}
@@ -726,7 +729,7 @@ func (c_ MockZapEncoderAddUint64Call) Do(f func(key string, value uint64)) MockZ
 }
 
 // AddUint8(key string, value uint8)
-func (r_ *MockZapEncoderMockRecorder) AddUint8(key interface{}, value interface{}) MockZapEncoderAddUint8Call {
+func (r_ *MockZapEncoderMockRecorder) AddUint8(key any, value any) MockZapEncoderAddUint8Call {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddUint8", reflect.TypeOf((*MockZapEncoder)(nil).AddUint8), key, value)
 	return MockZapEncoderAddUint8Call{call}
@@ -748,7 +751,7 @@ func (c_ MockZapEncoderAddUint8Call) Do(f func(key string, value uint8)) MockZap
 }
 
 // AddUintptr(key string, value uintptr)
-func (r_ *MockZapEncoderMockRecorder) AddUintptr(key interface{}, value interface{}) MockZapEncoderAddUintptrCall {
+func (r_ *MockZapEncoderMockRecorder) AddUintptr(key any, value any) MockZapEncoderAddUintptrCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AddUintptr", reflect.TypeOf((*MockZapEncoder)(nil).AddUintptr), key, value)
 	return MockZapEncoderAddUintptrCall{call}
@@ -798,7 +801,7 @@ func (c_ MockZapEncoderCloneCall) Return(res0 zapcore.Encoder) MockZapEncoderClo
 }
 
 // EncodeEntry(zapcore.Entry, []zapcore.Field) (*buffer.Buffer, error)
-func (r_ *MockZapEncoderMockRecorder) EncodeEntry(arg interface{}, arg2 interface{}) MockZapEncoderEncodeEntryCall {
+func (r_ *MockZapEncoderMockRecorder) EncodeEntry(arg any, arg2 any) MockZapEncoderEncodeEntryCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "EncodeEntry", reflect.TypeOf((*MockZapEncoder)(nil).EncodeEntry), arg, arg2)
 	return MockZapEncoderEncodeEntryCall{call}
@@ -826,7 +829,7 @@ func (c_ MockZapEncoderEncodeEntryCall) Return(res0 *buffer.Buffer, res1 error)
 }
 
 // OpenNamespace(key string)
-func (r_ *MockZapEncoderMockRecorder) OpenNamespace(key interface{}) MockZapEncoderOpenNamespaceCall {
+func (r_ *MockZapEncoderMockRecorder) OpenNamespace(key any) MockZapEncoderOpenNamespaceCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "OpenNamespace", reflect.TypeOf((*MockZapEncoder)(nil).OpenNamespace), key)
 	return MockZapEncoderOpenNamespaceCall{call}
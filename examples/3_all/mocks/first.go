@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: github.com/skipor/gmg/examples/3_all.First
+// Method set hash: First=e74e31a1fd97aacd
+// Command: gmg --src github.com/skipor/gmg/examples/3_all --dst ./first.go --pkg mocks_example First
+// Body hash: dc0cab711bc155e6
 
 package mocks_example
 
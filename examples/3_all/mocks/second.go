@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: github.com/skipor/gmg/examples/3_all.Second
+// Method set hash: Second=7efc37d182c36536
+// Command: gmg --src github.com/skipor/gmg/examples/3_all --dst ./second.go --pkg mocks_example Second
+// Body hash: 4e2d8cd33e4d9fcc
 
 package mocks_example
 
@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: github.com/skipor/gmg/examples/4_all-file.A1
+// Method set hash: A1=cd7b59b3b3d93038
+// Command: gmg --src github.com/skipor/gmg/examples/4_all-file --dst ./a_1.go --pkg mocks_example A1
+// Body hash: f10f450f52373314
 
 package mocks_example
 
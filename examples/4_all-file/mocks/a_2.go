@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: github.com/skipor/gmg/examples/4_all-file.A2
+// Method set hash: A2=4cc751db54420139
+// Command: gmg --src github.com/skipor/gmg/examples/4_all-file --dst ./a_2.go --pkg mocks_example A2
+// Body hash: ae6d632b1f0903be
 
 package mocks_example
 
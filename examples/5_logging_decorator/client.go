@@ -0,0 +1,16 @@
+package example
+
+import (
+	"context"
+)
+
+// `--kind logging` generates decorator, that logs calls via *slog.Logger.
+// Params marked with `//gmg:redact` comment are not logged.
+//go:generate gmg --kind logging --dst ./logging_{}.go
+
+// Client is an example interface.
+type Client interface {
+	Get(ctx context.Context, key string) (string, error)
+	//gmg:redact token
+	Auth(ctx context.Context, user, token string) error
+}
@@ -0,0 +1,31 @@
+package example
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type client struct{}
+
+func (client) Get(context.Context, string) (string, error) { return "value", nil }
+func (client) Auth(context.Context, string, string) error  { return nil }
+
+func TestLoggingClient(t *testing.T) {
+	buf := &bytes.Buffer{}
+	c := NewLoggingClient(client{}, slog.New(slog.NewTextHandler(buf, nil)))
+
+	val, err := c.Get(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", val)
+	err = c.Auth(context.Background(), "user", "secret")
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "msg=Client.Get key=key res0=value")
+	assert.Contains(t, buf.String(), "msg=Client.Auth user=user token=REDACTED")
+	assert.NotContains(t, buf.String(), "secret")
+}
@@ -0,0 +1,61 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: github.com/skipor/gmg/examples/5_logging_decorator.Client
+// Method set hash: Client=8980809df0e4fe9b
+// Command: gmg --src github.com/skipor/gmg/examples/5_logging_decorator --dst ./logging_client.go --pkg example --kind logging Client
+// Body hash: 2b3a4963d39a80dd
+
+package example
+
+import (
+	context "context"
+	slog "log/slog"
+	time "time"
+)
+
+// NewLoggingClient creates a new LoggingClient, that calls next and logs calls to log.
+func NewLoggingClient(next Client, log *slog.Logger) *LoggingClient {
+	return &LoggingClient{next: next, log: log}
+}
+
+// LoggingClient is a logging decorator of github.com/skipor/gmg/examples/5_logging_decorator.Client.
+// Successful calls are logged with info level, and calls that returned non-nil error with error level.
+type LoggingClient struct {
+	next Client
+	log  *slog.Logger
+}
+
+// Auth implements github.com/skipor/gmg/examples/5_logging_decorator.Client.
+func (d_ *LoggingClient) Auth(ctx context.Context, user string, token string) error {
+	start_ := time.Now()
+	res0 := d_.next.Auth(ctx, user, token)
+	attrs_ := []slog.Attr{
+		slog.Any("user", user),
+		slog.String("token", "REDACTED"),
+		slog.Duration("duration", time.Since(start_)),
+	}
+	level_ := slog.LevelInfo
+	if res0 != nil {
+		level_ = slog.LevelError
+		attrs_ = append(attrs_, slog.Any("error", res0))
+	}
+	d_.log.LogAttrs(ctx, level_, "Client.Auth", attrs_...)
+	return res0
+}
+
+// Get implements github.com/skipor/gmg/examples/5_logging_decorator.Client.
+func (d_ *LoggingClient) Get(ctx context.Context, key string) (string, error) {
+	start_ := time.Now()
+	res0, res1 := d_.next.Get(ctx, key)
+	attrs_ := []slog.Attr{
+		slog.Any("key", key),
+		slog.Any("res0", res0),
+		slog.Duration("duration", time.Since(start_)),
+	}
+	level_ := slog.LevelInfo
+	if res1 != nil {
+		level_ = slog.LevelError
+		attrs_ = append(attrs_, slog.Any("error", res1))
+	}
+	d_.log.LogAttrs(ctx, level_, "Client.Get", attrs_...)
+	return res0, res1
+}
@@ -0,0 +1,30 @@
+package example
+
+import (
+	"context"
+)
+
+// `--shared-runtime` declares call wrappers as aliases of github.com/skipor/gmg/pkg/gmgrt generic types,
+// so generated mocks are much smaller, but still have typed Return, Do and DoAndReturn.
+//go:generate gmg --shared-runtime
+
+// Store is an example interface.
+type Store interface {
+	Get(ctx context.Context, key string) (value string, ok bool, err error)
+	Put(ctx context.Context, key string, value string) error
+	Delete(ctx context.Context, keys ...string)
+}
+
+// Move moves value from one key to another.
+func Move(ctx context.Context, s Store, from, to string) error {
+	value, ok, err := s.Get(ctx, from)
+	if err != nil || !ok {
+		return err
+	}
+	err = s.Put(ctx, to, value)
+	if err != nil {
+		return err
+	}
+	s.Delete(ctx, from)
+	return nil
+}
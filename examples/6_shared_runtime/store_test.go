@@ -0,0 +1,28 @@
+package example
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mocks_shared_runtime "github.com/skipor/gmg/examples/6_shared_runtime/mocks"
+)
+
+func TestMove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := mocks_shared_runtime.NewMockStore(ctrl)
+	ctx := context.Background()
+	s.EXPECT().Get(ctx, "from").Return("value", true, nil)
+	s.EXPECT().Put(ctx, "to", "value").DoAndReturn(func(ctx context.Context, key string, value string) error {
+		assert.Equal(t, "to", key)
+		return nil
+	})
+	s.EXPECT().Delete(ctx, "from").Do(func(ctx context.Context, keys ...string) {
+		assert.Equal(t, []string{"from"}, keys)
+	})
+	err := Move(ctx, s, "from", "to")
+	require.NoError(t, err)
+}
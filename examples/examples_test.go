@@ -42,7 +42,8 @@ func TestExamplesGoGenerate(t *testing.T) {
 				}
 			}
 			cmd := exec.Command("go", "generate", "-x", "./"+dir+"/...")
-			cmd.Env = append(os.Environ(), "GMG_DEBUG=true", PATH)
+			// Generated files are always rendered, to check that examples are up to date with gmg code.
+			cmd.Env = append(os.Environ(), "GMG_DEBUG=true", "GMG_NO_CACHE=true", PATH)
 			out, err := cmd.CombinedOutput()
 			t.Logf("%s\n%s", cmd.String(), out)
 			require.NoError(t, err, "go generate failed")
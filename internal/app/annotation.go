@@ -0,0 +1,156 @@
+package app
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"go.uber.org/zap"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/skipor/gmg/pkg/gmg"
+)
+
+// mockDirective marks interface, that is selected by --annotated.
+// It is put in interface doc comment, optionally followed by options, that override flags for the interface:
+// `//gmg:mock dst=./mocks name=FakeStore`.
+// Name option is applied, even when interface is selected other way, so regeneration by recorded command keeps it.
+const mockDirective = "//gmg:mock"
+
+// annotation is options of mockDirective.
+type annotation struct {
+	// destination overrides --dst, when set. It is relative to dir of interface package, whatever --src is.
+	destination string
+	// mockName overrides generated mock type name, when set.
+	mockName string
+}
+
+// parseAnnotation returns options of mockDirective, if doc comment contains it.
+func parseAnnotation(doc *ast.CommentGroup) (annotation, bool, error) {
+	if doc == nil {
+		return annotation{}, false, nil
+	}
+	var args string
+	var found bool
+	for _, c := range doc.List {
+		if c.Text == mockDirective || strings.HasPrefix(c.Text, mockDirective+" ") {
+			args, found = strings.TrimPrefix(c.Text, mockDirective), true
+			break
+		}
+	}
+	if !found {
+		return annotation{}, false, nil
+	}
+	var a annotation
+	for _, option := range strings.Fields(args) {
+		key, value, ok := strings.Cut(option, "=")
+		if !ok || value == "" {
+			return annotation{}, true, fmt.Errorf("option '%s' is not in 'key=value' format", option)
+		}
+		switch key {
+		case "dst":
+			a.destination = path.Clean(value)
+		case "name":
+			if !token.IsIdentifier(value) {
+				return annotation{}, true, fmt.Errorf("name '%s' is not valid Go identifier", value)
+			}
+			a.mockName = value
+		default:
+			return annotation{}, true, fmt.Errorf("unknown option '%s', expected one of: dst, name", key)
+		}
+	}
+	return a, true, nil
+}
+
+// selectAnnotatedInterfaces returns interfaces of package of kind, which doc comment contains mockDirective.
+// Mock names set by annotations are put to gmg.Interface.MockName.
+func selectAnnotatedInterfaces(log *zap.SugaredLogger, fs afero.Fs, pkgs []*packages.Package, kind packageKind) ([]gmg.Interface, error) {
+	all, err := selectAllPackagesInterfaces(log, pkgs, kind)
+	if err != nil || len(all) == 0 {
+		return nil, err
+	}
+	loadInterfacesSyntax(log, fs, pkgs, all)
+	var ifaces []gmg.Interface
+	for _, iface := range all {
+		if iface.Syntax == nil {
+			continue
+		}
+		a, ok, err := parseAnnotation(iface.Syntax.Doc)
+		if err != nil {
+			return nil, fmt.Errorf("interface %s %s comment: %w", iface.Name, mockDirective, err)
+		}
+		if !ok {
+			continue
+		}
+		iface.MockName = a.mockName
+		ifaces = append(ifaces, iface)
+	}
+	if len(ifaces) == 0 {
+		log.Infof("No interfaces annotated with %s comment found in %s package", mockDirective, kind)
+	}
+	return ifaces, nil
+}
+
+// setAnnotatedMockNames sets gmg.Interface.MockName of interfaces, which doc comment contains mockDirective with name option.
+func setAnnotatedMockNames(ifaces []gmg.Interface) error {
+	for i := range ifaces {
+		if ifaces[i].Syntax == nil {
+			continue
+		}
+		a, _, err := parseAnnotation(ifaces[i].Syntax.Doc)
+		if err != nil {
+			return fmt.Errorf("interface %s %s comment: %w", ifaces[i].Name, mockDirective, err)
+		}
+		ifaces[i].MockName = a.mockName
+	}
+	return nil
+}
+
+// destinationGroup is interfaces, that are generated to the same destination.
+type destinationGroup struct {
+	// baseDir is dir, that destination is relative to. It is relative to Environment.Dir.
+	baseDir     string
+	destination string
+	ifaces      []gmg.Interface
+}
+
+// groupByDestination groups interfaces by destination, that is overridden by annotation dst option.
+// Without --annotated, all interfaces are in one group with --dst destination relative to baseDir, even if there are no interfaces.
+// Annotation dst option is relative to dir of interface package.
+func groupByDestination(env *Environment, params *params, pkgs []*packages.Package, baseDir string, ifaces []gmg.Interface) ([]destinationGroup, error) {
+	if !params.Selector.annotated {
+		return []destinationGroup{{baseDir: baseDir, destination: params.Destination, ifaces: ifaces}}, nil
+	}
+	var groups []destinationGroup
+	index := map[[2]string]int{}
+	for _, iface := range ifaces {
+		groupBaseDir, destination := baseDir, params.Destination
+		if iface.Syntax != nil {
+			// Annotation is already validated on select.
+			if a, _, _ := parseAnnotation(iface.Syntax.Doc); a.destination != "" {
+				pkg, _ := lookupInterfaceObject(pkgs, iface)
+				if pkg == nil || packageDir(pkg) == "" {
+					return nil, fmt.Errorf("interface %s package dir is unknown, so %s dst option can't be resolved", iface.Name, mockDirective)
+				}
+				dir, err := filepath.Rel(env.Dir, packageDir(pkg))
+				if err != nil {
+					return nil, fmt.Errorf("interface %s package dir: %w", iface.Name, err)
+				}
+				groupBaseDir, destination = dir, a.destination
+			}
+		}
+		key := [2]string{groupBaseDir, destination}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, destinationGroup{baseDir: groupBaseDir, destination: destination})
+		}
+		groups[i].ifaces = append(groups[i].ifaces, iface)
+	}
+	return groups, nil
+}
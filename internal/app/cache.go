@@ -0,0 +1,434 @@
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"hash"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"go.uber.org/zap"
+	"golang.org/x/mod/modfile"
+
+	"github.com/skipor/gmg/pkg/gmg"
+	"github.com/skipor/gmg/pkg/gogen"
+)
+
+// generationCache is content-addressed cache of generated files, that is stored in Environment.CacheDir.
+// Key is hash of everything, that generated files depend on: gmg executable and params, Go toolchain, build environment,
+// Go files of source package and packages of the same module, that it imports transitively,
+// go.mod and go.sum, that pin versions of other modules.
+// Standard library is not tracked, as Go 1 compatibility promise doesn't allow exported interfaces changes.
+// Cache is not used, when packages of other modules may be loaded from local dirs, which are not tracked:
+// with go.work workspace, go.mod replace by local dir, or vendor dir.
+// When key is found in cache, packages load and rendering are skipped entirely.
+// Entries, that were not used for cacheTrimAge, are deleted, like go build cache does.
+type generationCache struct {
+	log *zap.SugaredLogger
+	fs  afero.Fs
+	// dir is Environment.CacheDir.
+	dir  string
+	path string
+}
+
+const (
+	// cacheTrimAge is age of entry last use, after which it is deleted.
+	cacheTrimAge = 5 * 24 * time.Hour
+	// cacheTrimInterval is min interval between cache dir scans for old entries.
+	cacheTrimInterval = 24 * time.Hour
+	// cacheUseUpdateInterval is min interval between entry modification time updates on use.
+	// Updates are not done on every use, to not write on every run.
+	cacheUseUpdateInterval = time.Hour
+	// cacheTrimFile contains unix time of last trim.
+	cacheTrimFile = "trim.txt"
+)
+
+// buildEnvVars are environment variables, that affect package load.
+var buildEnvVars = []string{"GOOS", "GOARCH", "GOFLAGS", "CGO_ENABLED", "GOEXPERIMENT", "GOWORK", "GOPATH"}
+
+// newGenerationCache returns cache for params, or nil if cache can't be used for them.
+func newGenerationCache(env *Environment, params *params) *generationCache {
+	log := params.Log
+	switch {
+	case env.CacheDir == "" || params.NoCache:
+		return nil
+	case params.Prune:
+		// Stale files depend on destination dir content, that is not part of key.
+		log.Debugf("Generation cache is not used, as pruning")
+		return nil
+	case isRecursivePattern(params.Source):
+		log.Debugf("Generation cache is not used for recursive source pattern")
+		return nil
+	}
+	key, err := generationKey(env, params)
+	if err != nil {
+		log.Debugf("Generation cache is not used: %s", err)
+		return nil
+	}
+	return &generationCache{
+		log:  log,
+		fs:   env.fs(),
+		dir:  env.CacheDir,
+		path: filepath.Join(env.CacheDir, key[:2], key),
+	}
+}
+
+type cachedFile struct {
+	Path    string
+	Content []byte
+}
+
+// get returns cached files, or false if there are no such.
+func (c *generationCache) get() ([]*gogen.File, bool) {
+	data, err := afero.ReadFile(c.fs, c.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			c.log.Warnf("Generation cache read failed: %s", err)
+		}
+		c.log.Debugf("Generation cache miss: %s", c.path)
+		return nil, false
+	}
+	var cached []cachedFile
+	err = json.Unmarshal(data, &cached)
+	if err != nil {
+		c.log.Warnf("Generation cache entry %s is corrupted: %s", c.path, err)
+		return nil, false
+	}
+	c.log.Debugf("Generation cache hit: %s", c.path)
+	c.markUsed(c.path)
+	c.trim()
+	files := make([]*gogen.File, len(cached))
+	for i, f := range cached {
+		files[i] = gogen.NewRenderedFile(f.Path, f.Content)
+	}
+	return files, true
+}
+
+// put renders files and puts them to cache. Failure is only logged, as cache is optional.
+func (c *generationCache) put(files []*gogen.File) {
+	err := c.put0(files)
+	if err != nil {
+		c.log.Warnf("Generation cache write failed: %s", err)
+	}
+	c.trim()
+}
+
+func (c *generationCache) put0(files []*gogen.File) error {
+	cached := make([]cachedFile, len(files))
+	for i, f := range files {
+		content, err := f.Content()
+		if err != nil {
+			// Render error will be reported on write.
+			return nil
+		}
+		cached[i] = cachedFile{Path: f.Path(), Content: content}
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	err = c.fs.MkdirAll(filepath.Dir(c.path), 0755)
+	if err != nil {
+		return err
+	}
+	// Concurrent gmg processes may put the same entry, so it is written atomically.
+	tmp, err := afero.TempFile(c.fs, filepath.Dir(c.path), filepath.Base(c.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = c.fs.Rename(tmp.Name(), c.path)
+	}
+	if err != nil {
+		_ = c.fs.Remove(tmp.Name())
+	}
+	return err
+}
+
+// markUsed updates entry modification time, so it is not trimmed.
+func (c *generationCache) markUsed(path string) {
+	info, err := c.fs.Stat(path)
+	if err != nil || time.Since(info.ModTime()) < cacheUseUpdateInterval {
+		return
+	}
+	now := time.Now()
+	_ = c.fs.Chtimes(path, now, now)
+}
+
+// trim deletes entries, that were not used for cacheTrimAge. Cache dir is scanned at most once per cacheTrimInterval.
+// Failures are only logged, as cache may be also deleted manually.
+func (c *generationCache) trim() {
+	trimFile := filepath.Join(c.dir, cacheTrimFile)
+	now := time.Now()
+	if data, err := afero.ReadFile(c.fs, trimFile); err == nil {
+		if last, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err == nil && now.Sub(time.Unix(last, 0)) < cacheTrimInterval {
+			return
+		}
+	}
+	err := afero.WriteFile(c.fs, trimFile, []byte(strconv.FormatInt(now.Unix(), 10)), 0644)
+	if err != nil {
+		c.log.Warnf("Generation cache trim failed: %s", err)
+		return
+	}
+	entries, _ := afero.Glob(c.fs, filepath.Join(c.dir, "??", "*"))
+	var deleted int
+	for _, path := range entries {
+		info, err := c.fs.Stat(path)
+		if err != nil || info.IsDir() || now.Sub(info.ModTime()) < cacheTrimAge {
+			continue
+		}
+		if c.fs.Remove(path) == nil {
+			deleted++
+		}
+	}
+	c.log.Debugf("Generation cache trimmed: %v of %v entries deleted", deleted, len(entries))
+}
+
+// generationKey returns hex hash of everything, that generated files depend on.
+// Error is returned, when key can't be computed. For example, when source package is not in module.
+func generationKey(env *Environment, params *params) (string, error) {
+	var srcDir string
+	m, inModule := findModule(env, ".")
+	if isLocalSource(params.Source) {
+		srcDir = env.abs(params.Source)
+		m, inModule = findModule(env, srcDir)
+	} else if inModule {
+		var ok bool
+		srcDir, ok = m.importPathDir(params.Source)
+		if !ok {
+			return "", fmt.Errorf("source package %s is not in module %s", params.Source, m.Path)
+		}
+	}
+	if !inModule {
+		return "", fmt.Errorf("source package is not in module")
+	}
+	err := checkLocalDependencies(env, m)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	p := func(format string, a ...interface{}) { _, _ = fmt.Fprintf(h, format, a...) }
+	p("gmg %s\n", gmgVersion)
+	// Version is not changed on every gmg code change, but executable is rebuilt.
+	p("executable %s\n", executableIdentity())
+	// Standard library interfaces and go/types behaviour are changed between Go releases.
+	toolchain, err := goToolchain(env)
+	if err != nil {
+		return "", err
+	}
+	p("go %s %s\n", runtime.Version(), toolchain)
+	p("source %s %s\n", params.Source, srcDir)
+	p("destination %s\n", params.Destination)
+	p("package %s\n", params.Package)
+	p("kind %s\n", params.Kind)
+	p("mode %s\n", params.Mode)
+	p("shared runtime %v\n", params.SharedRuntime)
+	p("selector %q %v %v %v %s %+v %v %v\n", params.Selector.names, params.Selector.all, params.Selector.allFile, params.Selector.annotated,
+		params.Selector.packageKind, params.Selector.goGenEnv, params.Selector.match, params.Selector.exclude)
+	for _, key := range buildEnvVars {
+		p("env %s=%s\n", key, env.Getenv(key))
+	}
+	if params.TemplatePath != "" {
+		err := hashFile(h, env.fs(), params.TemplatePath)
+		if err != nil {
+			return "", fmt.Errorf("template: %w", err)
+		}
+	}
+	srcName, err := dirPackageName(env.fs(), srcDir)
+	if err != nil {
+		return "", fmt.Errorf("source package name: %w", err)
+	}
+	dstDir, _ := splitDestination(params.Destination, srcName)
+	p("destination dir %s\n", env.abs(dstDir))
+	// Destination may be in other module, which go directive affects generated code.
+	p("destination go version %s\n", goVersion(env, filepath.Join(env.Dir, dstDir), nil))
+	if params.Package == "" {
+		// Package name is deduced from destination dir package, that is changed, when generated files are written.
+		// So, deduced name is hashed, but not files.
+		name, err := dirPackageName(env.fs(), filepath.Join(env.Dir, dstDir))
+		if err != nil {
+			return "", fmt.Errorf("destination package name: %w", err)
+		}
+		if name == "" {
+			name = strings.ReplaceAll(defaultPackageNameTemplate, placeHolder, srcName)
+		}
+		p("destination package %s\n", name)
+	}
+	for _, path := range []string{"go.mod", "go.sum"} {
+		err := hashFile(h, env.fs(), filepath.Join(m.Dir, path))
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+	}
+	err = hashPackageDirs(h, env.fs(), m, srcDir)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// goToolchain returns version and GOROOT of go command, that packages are loaded with.
+// It may differ from Go version gmg is built with.
+func goToolchain(env *Environment) (string, error) {
+	cmd := exec.Command("go", "env", "GOVERSION", "GOROOT")
+	cmd.Dir = env.Dir
+	cmd.Env = env.Env
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("go env: %w\n%s", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.Join(strings.Fields(string(out)), " "), nil
+}
+
+// checkLocalDependencies returns error, when module dependencies may be loaded from local dirs,
+// which files are not hashed: go.work workspace is used, go.mod replaces module by local dir, or vendor dir exists.
+func checkLocalDependencies(env *Environment, m module) error {
+	if gowork := env.Getenv("GOWORK"); gowork != "" && gowork != "off" {
+		return fmt.Errorf("go.work workspace is used")
+	} else if gowork == "" {
+		for dir := env.abs("."); ; dir = filepath.Dir(dir) {
+			if _, err := env.fs().Stat(filepath.Join(dir, "go.work")); err == nil {
+				return fmt.Errorf("go.work workspace is used")
+			}
+			if filepath.Dir(dir) == dir {
+				break
+			}
+		}
+	}
+	goModPath := filepath.Join(m.Dir, "go.mod")
+	data, err := afero.ReadFile(env.fs(), goModPath)
+	if err != nil {
+		return err
+	}
+	f, err := modfile.Parse(goModPath, data, nil)
+	if err != nil {
+		return err
+	}
+	for _, r := range f.Replace {
+		if r.New.Version == "" {
+			return fmt.Errorf("go.mod replaces %s by local dir %s", r.Old.Path, r.New.Path)
+		}
+	}
+	if info, err := env.fs().Stat(filepath.Join(m.Dir, "vendor")); err == nil && info.IsDir() {
+		return fmt.Errorf("vendor dir is used")
+	}
+	return nil
+}
+
+// hashPackageDirs hashes Go files of srcDir, and dirs of the module packages, that it imports transitively.
+func hashPackageDirs(h hash.Hash, fs afero.Fs, m module, srcDir string) error {
+	visited := map[string]bool{}
+	queue := []string{srcDir}
+	for len(queue) != 0 {
+		dir := queue[0]
+		queue = queue[1:]
+		if visited[dir] {
+			continue
+		}
+		visited[dir] = true
+		infos, err := afero.ReadDir(fs, dir)
+		if err != nil {
+			return fmt.Errorf("package dir: %w", err)
+		}
+		_, _ = fmt.Fprintf(h, "package dir %s\n", dir)
+		for _, info := range infos {
+			name := info.Name()
+			if info.IsDir() || !strings.HasSuffix(name, ".go") {
+				continue
+			}
+			// Test files of imported packages are not part of them.
+			if dir != srcDir && strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			content, err := afero.ReadFile(fs, filepath.Join(dir, name))
+			if err != nil {
+				return err
+			}
+			if _, ok := gmg.ParseHeader(content); ok {
+				// Generated files are output, and they may be put to source dir.
+				// Otherwise, every run after write would miss.
+				continue
+			}
+			_, _ = fmt.Fprintf(h, "file %s %v\n", name, len(content))
+			_, _ = h.Write(content)
+			file, _ := parser.ParseFile(token.NewFileSet(), name, content, parser.ImportsOnly)
+			if file == nil {
+				continue
+			}
+			for _, imp := range file.Imports {
+				importPath, err := strconv.Unquote(imp.Path.Value)
+				if err != nil {
+					continue
+				}
+				if importDir, ok := m.importPathDir(importPath); ok {
+					queue = append(queue, importDir)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func hashFile(h hash.Hash, fs afero.Fs, path string) error {
+	f, err := fs.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, _ = fmt.Fprintf(h, "file %s\n", path)
+	_, err = io.Copy(h, f)
+	return err
+}
+
+// dirPackageName returns package name of non-test Go files in dir, or empty string, if there are no such.
+// Different names are joined, as that is not valid package, but still should be hashed.
+func dirPackageName(fs afero.Fs, dir string) (string, error) {
+	infos, err := afero.ReadDir(fs, dir)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	names := map[string]bool{}
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		content, err := afero.ReadFile(fs, filepath.Join(dir, name))
+		if err != nil {
+			return "", err
+		}
+		file, _ := parser.ParseFile(token.NewFileSet(), name, content, parser.PackageClauseOnly)
+		if file != nil && file.Name != nil {
+			names[file.Name.Name] = true
+		}
+	}
+	var sorted []string
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return strings.Join(sorted, " "), nil
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+	"go.uber.org/zap"
+
+	"github.com/skipor/gmg/pkg/gogen"
+)
+
+// checkFiles compares generated files with existing, and prints unified diff of stale ones to Environment.Stdout,
+// so it can be piped separately from logs.
+// Previously generated files, that should be deleted, are stale too.
+func checkFiles(log *zap.SugaredLogger, env *Environment, files []*gogen.File, deleted []string) error {
+	var stale int
+	for _, f := range files {
+		diff, err := fileDiff(env, f)
+		if err != nil {
+			return fmt.Errorf("file %s: %w", f.Path(), err)
+		}
+		if diff == "" {
+			log.Debugf("File %s is up to date", f.Path())
+			continue
+		}
+		stale++
+		_, _ = fmt.Fprint(env.Stdout, diff)
+	}
+	for _, path := range deleted {
+		existing, err := afero.ReadFile(env.fs(), path)
+		if err != nil {
+			return fmt.Errorf("file %s: read: %w", path, err)
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(existing)),
+			FromFile: "a/" + path,
+			ToFile:   os.DevNull,
+			Context:  3,
+		})
+		if err != nil {
+			return fmt.Errorf("file %s: %w", path, err)
+		}
+		_, _ = fmt.Fprint(env.Stdout, diff)
+	}
+	if len(deleted) != 0 {
+		return fmt.Errorf("%v of %v generated files are stale, and %v files should be deleted, regenerate them with --prune", stale, len(files), len(deleted))
+	}
+	if stale != 0 {
+		return fmt.Errorf("%v of %v generated files are stale, regenerate them", stale, len(files))
+	}
+	return nil
+}
+
+// fileDiff returns unified diff between existing and generated file content, or empty string, if they are equal.
+func fileDiff(env *Environment, f *gogen.File) (string, error) {
+	content, err := f.Content()
+	if err != nil {
+		return "", fmt.Errorf("render: %w", err)
+	}
+	fromFile := "a/" + f.Path()
+	existing, err := afero.ReadFile(env.fs(), f.Path())
+	if os.IsNotExist(err) {
+		fromFile = os.DevNull
+	} else if err != nil {
+		return "", fmt.Errorf("read: %w", err)
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(existing)),
+		B:        difflib.SplitLines(string(content)),
+		FromFile: fromFile,
+		ToFile:   "b/" + f.Path(),
+		Context:  3,
+	})
+}
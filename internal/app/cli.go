@@ -2,30 +2,63 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
+	"path/filepath"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/spf13/afero"
 	"github.com/spf13/pflag"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/skipor/gmg/pkg/gmg"
+	"github.com/skipor/gmg/pkg/gogen"
 )
 
-const gmgVersion = "0.11.0"
+const gmgVersion = "0.12.0"
 
 func Main(env *Environment) int {
+	if exitCode, ok := forward(env); ok {
+		return exitCode
+	}
+	return runMain(context.Background(), env, nil)
+}
+
+// runMain runs gmg in this process. Source packages are loaded via loader, when it is set.
+func runMain(ctx context.Context, env *Environment, loader sourceLoader) int {
 	isGoGenerate := env.Getenv("GOFILE") != ""
 	if isGoGenerate {
 		// Separate different 'go generate' run logs
 		fmt.Fprintf(env.Stderr, "\n")
 		defer fmt.Fprintf(env.Stderr, "\n")
 	}
+	if len(env.Args) != 0 {
+		var subcommand func(ctx context.Context, env *Environment, args []string) error
+		switch env.Args[0] {
+		case regenSubcommand:
+			subcommand = regen
+		case generateSubcommand:
+			subcommand = generateDirectives
+		case serveSubcommand:
+			subcommand = serve
+		}
+		if subcommand != nil {
+			err := subcommand(ctx, env, env.Args[1:])
+			if errors.Is(err, errExitZero) {
+				return 0
+			}
+			return handleError(env, err)
+		}
+	}
 	params, err := loadParams(env)
 	if errors.Is(err, errExitZero) {
 		return 0
@@ -33,17 +66,42 @@ func Main(env *Environment) int {
 	if err != nil {
 		return handleError(env, err)
 	}
-	err = run(env, params)
+	params.Loader = loader
+	err = run(ctx, env, params)
 	return handleError(env, err)
 }
 
+// Generate parses env.Args like Main, loads packages and renders files, but doesn't write them.
+func Generate(ctx context.Context, env *Environment) ([]*gogen.File, error) {
+	params, err := loadParams(env)
+	if err != nil {
+		return nil, err
+	}
+	res, err := generate(ctx, env, params)
+	if err != nil {
+		return nil, err
+	}
+	return res.Files, nil
+}
+
 type Environment struct {
-	Args   []string
+	Args []string
+	// Stdout is used only for generated file output, when '--dst -' passed, and for --check diffs.
+	Stdout io.Writer
 	Stderr io.Writer
 	Dir    string
 	Env    []string
-	// Fs is for output only. Go tooling invoked under hood, that read real files.
+	// Fs is used for all file reads and writes. Relative paths are resolved against Dir.
+	// Go files of source and destination packages are passed to Go tooling invoked under hood as overlay,
+	// so they may be not on disk, when Fs is not OS file system.
 	Fs afero.Fs
+	// LogCore is optional logging core, that is used instead of logging to Stderr.
+	LogCore zapcore.Core
+	// CacheDir is optional dir of generation cache. Cache is not used, when it is empty.
+	CacheDir string
+	// ServerSocket is optional path of gmg server Unix socket, that is set by GMG_SOCKET environment variable.
+	// When server is listening on it, run is forwarded to server, instead of being done in this process.
+	ServerSocket string
 }
 
 func (e *Environment) Getenv(key string) string {
@@ -67,11 +125,16 @@ func RealEnvironment() *Environment {
 		panic(fmt.Sprintf("get workdir: %+v", err))
 	}
 	return &Environment{
-		Args:   os.Args[1:],
-		Stderr: os.Stderr,
-		Dir:    dir,
-		Fs:     afero.NewOsFs(),
-		Env:    os.Environ(),
+		Args:     os.Args[1:],
+		Stdout:   os.Stdout,
+		Stderr:   os.Stderr,
+		Dir:      dir,
+		Fs:       afero.NewOsFs(),
+		Env:      os.Environ(),
+		CacheDir: defaultCacheDir(),
+		// Server is used only by CLI, as in other cases process is already warm.
+		// Forwarding is opt-in, as server runs are done in other process, with its own state.
+		ServerSocket: os.Getenv(serverSocketEnv),
 	}
 }
 
@@ -99,18 +162,34 @@ func loadParams(env *Environment) (*params, error) {
 		p := func(format string, a ...interface{}) { _, _ = fmt.Fprintf(b, format, a...) }
 		p("gmg is type-safe, fast and handy alternative GoMock generator. See details at: https://github.com/skipor/gmg\n")
 		p("\n")
-		p("Usage: gmg [--src <package path>] [--dst <file path>] [--pkg <package name>] <interface name> [<interface name> ...]\n\n")
+		p("Usage: gmg [--src <package path>] [--dst <file path>] [--pkg <package name>] <interface name> [<interface name> ...]\n")
+		p("       gmg regen [<path> ...] # Regenerate files, using commands recorded in their headers. Run 'gmg regen --help' for details.\n")
+		p("       gmg generate [<package pattern> ...] # Run all '//go:generate gmg' directives in one process. Run 'gmg generate --help' for details.\n")
+		p("       gmg serve [--socket <path>] # Run server, that keeps loaded packages cached, and that gmg runs are forwarded to, when GMG_SOCKET is set. Run 'gmg serve --help' for details.\n\n")
 		p("Flags:\n%s", fs.FlagUsages())
 		_, _ = b.WriteTo(env.Stderr)
 	}
 	var (
-		pkg     string
-		src     string
-		dst     string
-		debug   bool
-		version bool
-		all     bool
-		allFile bool
+		pkg       string
+		src       string
+		dst       string
+		debug     bool
+		version   bool
+		all       bool
+		allFile   bool
+		kind      string
+		tmpl      string
+		check     bool
+		force     bool
+		prune     bool
+		noCache   bool
+		noIncr    bool
+		mode      string
+		shared    bool
+		match     string
+		exclude   string
+		annotated bool
+		pkgKind   string
 	)
 	fs.StringVarP(&src, "src", "s", ".",
 		"Source Go package to search for interfaces. Absolute or relative.\n"+
@@ -119,7 +198,8 @@ func loadParams(env *Environment) (*params, error) {
 			"	.\n"+
 			"	./relative/pkg\n"+
 			"	github.com/third-party/pkg\n"+
-			"	io\n",
+			"	io\n"+
+			"Recursive pattern like './...' can be used with --all. Then --dst is resolved relative to each matched package dir.\n",
 	)
 	fs.StringVarP(&dst, "dst", "d", "./mocks",
 		"Destination directory or file relative path or pattern.\n"+
@@ -130,7 +210,8 @@ func loadParams(env *Environment) (*params, error) {
 			"	./mocks\n"+
 			"	./{}mocks\n"+
 			"	./mocks/{}_gomock.go\n"+
-			"	./mocks_test.go # All mocks will be put to single file.\n",
+			"	./mocks_test.go # All mocks will be put to single file.\n"+
+			"	- # All mocks will be put to single file, that is printed to stdout. Imports and package name are like in case of file in --src dir.\n",
 	)
 	fs.StringVarP(&pkg, "pkg", "p", "",
 		"Package name in generated files.\n"+
@@ -140,13 +221,87 @@ func loadParams(env *Environment) (*params, error) {
 			"	mocks_{} # mockgen style\n"+
 			"	{}mocks # mockery style\n")
 	fs.BoolVar(&all, "all", false,
-		"Select all interfaces in package.\n"+
-			"When called from //go:generate comment then package kind selected automatically: primary - other than *_test.go files; test - *_test.go files; black-box-test - package *_test\n",
+		"Select all interfaces in package of --package-kind.\n"+
+			"Primary package is selected by default, even when called from //go:generate comment in *_test.go file.\n",
+	)
+	fs.StringVar(&pkgKind, "package-kind", primaryPackageKind,
+		"Kind of package, which interfaces are selected by --all or --annotated. One of: primary, test, black-box-test, any.\n"+
+			"primary - other than *_test.go files; test - *_test.go files; black-box-test - package *_test; any - all of them.\n"+
+			"Test packages interfaces can be used only in the same package tests, so put mocks there: '--dst ./mocks_test.go'.\n"+
+			"Default is primary, including runs from //go:generate comment in *_test.go file.\n"+
+			"With any, generated code builds only as *_test.go file in the same package, like '--dst ./mocks_test.go',\n"+
+			"as test packages can't be imported. So, interfaces should not be in both test and black-box-test packages,\n"+
+			"and their names should be unique across packages, as their generated code would conflict otherwise.\n",
 	)
 	fs.BoolVar(&allFile, "all-file", false,
 		"Select all interfaces in current file, when called from //go:generate comment .\n",
 	)
-	fs.BoolVar(&debug, "debug", os.Getenv("GMG_DEBUG") != "", "Verbose debug logging.")
+	fs.BoolVar(&annotated, "annotated", false,
+		"Select all interfaces in package, which doc comment contains '//gmg:mock' directive.\n"+
+			"Directive options override flags for the interface:\n"+
+			"	dst - destination, like --dst, but relative to interface package dir.\n"+
+			"	name - mock type name. It is used for the interface mocks, even when it is selected by name, --all or --all-file,\n"+
+			"	so regenerated files keep it.\n"+
+			"Example:\n"+
+			"	//gmg:mock dst=./mocks/store_mock.go name=FakeStore\n",
+	)
+	fs.StringVar(&match, "match", "",
+		"Select only interfaces, which names match Go regexp, of ones selected by --all, --all-file or --annotated.\n"+
+			"Regexp is not anchored, so use '^Foo$' to match whole name.\n",
+	)
+	fs.StringVar(&exclude, "exclude", "",
+		"Don't select interfaces, which names match Go regexp, of ones selected by --all, --all-file or --annotated.\n"+
+			"Examples:\n"+
+			"	'^(Foo|Bar)$'\n"+
+			"	'Internal$'\n",
+	)
+	fs.StringVar(&kind, "kind", gmg.KindMock.String(),
+		"Kind of generated code. One of: "+kindsList()+".\n"+
+			"mock - GoMock with type-safe call wrappers.\n"+
+			"logging - Logging<Interface> decorator, that logs calls via *slog.Logger.\n"+
+			"	Method params marked with '//gmg:redact' comment are not logged.\n"+
+			"faulty - Faulty<Interface> decorator, that injects errors and latency according to Faulty<Interface>Policy.\n",
+	)
+	fs.StringVar(&tmpl, "template", "",
+		"Path to Go text/template file, that is used to generate code instead of --kind.\n"+
+			"Template is executed with github.com/skipor/gmg/pkg/gmg.TemplateData, and its output is put after generated file package clause.\n"+
+			"Use {{import \"path/to/pkg\"}} to import package and get its name. Imports and formatting are handled by gmg.\n",
+	)
+	fs.BoolVar(&check, "check", false,
+		"Don't write files, but check that they are up to date.\n"+
+			"Unified diff is printed to stdout for every stale file, and exit code is non-zero in that case.\n"+
+			"Useful in CI, instead of 'go generate ./... && git diff --exit-code'.\n",
+	)
+	fs.BoolVar(&force, "force", false,
+		"Overwrite existing files, that are not generated. That is, have no 'Code generated ... DO NOT EDIT.' comment.\n"+
+			"By default, gmg refuses to do that, as --dst typo may replace hand-written code.\n",
+	)
+	fs.BoolVar(&prune, "prune", false,
+		"Delete files in destination dir, that were generated by gmg from interfaces of source package, that are not exist or not selected anymore.\n"+
+			"Can be used only with --all or --all-file.\n",
+	)
+	fs.BoolVar(&noCache, "no-cache", env.Getenv("GMG_NO_CACHE") != "",
+		"Don't use generation cache, that allows to skip packages load and rendering, when nothing that generated files depend on has changed.\n"+
+			"Cache is stored in gmg dir of user cache dir, and can be safely deleted.\n"+
+			"Entries, that were not used for 5 days, are deleted automatically.\n",
+	)
+	fs.BoolVar(&noIncr, "no-incremental", env.Getenv("GMG_NO_INCREMENTAL") != "",
+		"Render mocks, even if they were generated by the same gmg executable from interfaces with the same method sets, that are recorded in header.\n",
+	)
+	fs.StringVar(&mode, "mode", string(packagesLoadMode),
+		"Way to load source package. One of: packages, source.\n"+
+			"packages - load source package and all its dependencies type information via go/packages.\n"+
+			"source - parse and type check only source package files, like mockgen -source does.\n"+
+			"	Packages of the same module are type checked from source on demand, and other packages are imported from compiled export data.\n"+
+			"	Works when dependencies fail to type check: unresolved types are printed as written in source.\n"+
+			"	Can't be used with recursive --src pattern.\n",
+	)
+	fs.BoolVar(&shared, "shared-runtime", false,
+		"Declare mock call wrappers as aliases of generic types from github.com/skipor/gmg/pkg/gmgrt, instead of generating dedicated types.\n"+
+			"Mocks are much smaller and faster to compile, but module should require github.com/skipor/gmg.\n"+
+			"Can be used only with mock kind.\n",
+	)
+	fs.BoolVar(&debug, "debug", env.Getenv("GMG_DEBUG") != "", "Verbose debug logging.")
 	fs.BoolVar(&version, "version", false, "Show version and exit.")
 	err := fs.Parse(env.Args)
 	if err != nil {
@@ -160,23 +315,23 @@ func loadParams(env *Environment) (*params, error) {
 		return nil, errExitZero
 	}
 
-	if strings.HasSuffix(src, "/...") {
-		return nil, fmt.Errorf("--src: can't use recursive pattern as a destination")
+	if isRecursivePattern(src) && !all && !annotated {
+		return nil, fmt.Errorf("--src: recursive pattern can be used only with --all or --annotated")
+	}
+	if !isKnownLoadMode(mode) {
+		return nil, fmt.Errorf("--mode: unknown mode '%s', expected one of: %s, %s", mode, packagesLoadMode, sourceLoadMode)
+	}
+	if isRecursivePattern(src) && loadMode(mode) == sourceLoadMode {
+		return nil, fmt.Errorf("--mode: recursive --src pattern can't be loaded in source mode")
 	}
 
-	encConf := zap.NewDevelopmentEncoderConfig()
-	encConf.TimeKey = ""
 	level := zapcore.WarnLevel
 	if debug {
 		level = zapcore.DebugLevel
 	}
-	log := zap.New(zapcore.NewCore(
-		zapcore.NewConsoleEncoder(encConf),
-		zapcore.AddSync(env.Stderr),
-		level,
-	)).Sugar()
+	log := newLogger(env, level)
 	log.Debugf("gmg version %s %s/%s", gmgVersion, runtime.GOOS, runtime.GOARCH)
-	log.Debugf("Run as: %q", os.Args)
+	log.Debugf("Run as: %q", env.Args)
 
 	interfaces := fs.Args()
 
@@ -195,34 +350,140 @@ func loadParams(env *Environment) (*params, error) {
 		GOPACKAGE: env.Getenv("GOPACKAGE"),
 	}
 	log.Debugf("Go env: %+v", goGenerateEnv)
-	if !goGenerateEnv.isSet() && len(interfaces) == 0 && !all && !allFile {
+	if !goGenerateEnv.isSet() && len(interfaces) == 0 && !all && !allFile && !annotated {
 		return nil, fmt.Errorf("pass interface names as arguments or use interface names selector like '--all'.\n" +
 			"Or put `//go:generate gmg` comment before interface declaration and run `go generate`.\n" +
 			"Run `gmg --help` to get more information.")
 	}
 
+	if !isKnownKind(kind) {
+		return nil, fmt.Errorf("--kind: unknown kind '%s', expected one of: %s", kind, kindsList())
+	}
+	if tmpl != "" && fs.Changed("kind") {
+		return nil, fmt.Errorf("can't use --kind and --template together")
+	}
+	var template *template.Template
+	var templatePath string
+	if tmpl != "" {
+		templatePath = env.abs(tmpl)
+		template, err = loadTemplate(env, tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("--template: %w", err)
+		}
+	}
+	if dst == stdoutDestination {
+		if isRecursivePattern(src) {
+			return nil, fmt.Errorf("--dst: can't print to stdout files of many packages matched by recursive --src pattern")
+		}
+		if check {
+			return nil, fmt.Errorf("can't use --check and '--dst -' together")
+		}
+	}
+	if prune && !all && !allFile {
+		return nil, fmt.Errorf("--prune can be used only with --all or --all-file")
+	}
+	if prune && dst == stdoutDestination {
+		return nil, fmt.Errorf("can't use --prune and '--dst -' together")
+	}
+	if shared && (tmpl != "" || gmg.Kind(kind) != gmg.KindMock) {
+		return nil, fmt.Errorf("--shared-runtime can be used only with mock kind")
+	}
 	if all && allFile {
 		return nil, fmt.Errorf("can't use --all and --all-file together")
 	}
 	if allFile && !goGenerateEnv.isSet() {
 		return nil, fmt.Errorf("--all-file can be used only when gmg called from //go:generate comment")
 	}
+	if annotated && (all || allFile || len(interfaces) != 0) {
+		return nil, fmt.Errorf("can't use --annotated together with --all, --all-file or interface names")
+	}
+	if annotated && dst == stdoutDestination {
+		return nil, fmt.Errorf("can't use --annotated and '--dst -' together")
+	}
+	if !isSelectablePackageKind(pkgKind) {
+		return nil, fmt.Errorf("--package-kind: unknown kind '%s', expected one of: %s, %s, %s, %s",
+			pkgKind, primaryPackageKind, testPackageKind, blackBoxTestPackageKind, anyPackageKind)
+	}
+	if fs.Changed("package-kind") && !all && !annotated {
+		return nil, fmt.Errorf("--package-kind can be used only with --all or --annotated")
+	}
+	if (match != "" || exclude != "") && !all && !allFile && !annotated {
+		return nil, fmt.Errorf("--match and --exclude can be used only with --all, --all-file or --annotated")
+	}
+	matchRegexp, err := compileSelectorRegexp("match", match)
+	if err != nil {
+		return nil, err
+	}
+	excludeRegexp, err := compileSelectorRegexp("exclude", exclude)
+	if err != nil {
+		return nil, err
+	}
 
 	return &params{
-		Log:         log,
-		Source:      src,
-		Destination: path.Clean(dst),
-		Package:     pkg,
+		Log:           log,
+		Source:        src,
+		Destination:   path.Clean(dst),
+		Package:       pkg,
+		Kind:          gmg.Kind(kind),
+		Template:      template,
+		TemplatePath:  templatePath,
+		Check:         check,
+		Force:         force,
+		Prune:         prune,
+		NoCache:       noCache,
+		NoIncremental: noIncr,
+		Mode:          loadMode(mode),
+		SharedRuntime: shared,
 		Selector: interfaceSelector{
-			names:    interfaces,
-			goGenEnv: goGenerateEnv,
-			all:      all,
-			allFile:  allFile,
+			names:       interfaces,
+			goGenEnv:    goGenerateEnv,
+			all:         all,
+			allFile:     allFile,
+			annotated:   annotated,
+			packageKind: packageKind(pkgKind),
+			match:       matchRegexp,
+			exclude:     excludeRegexp,
 		},
 	}, nil
 
 }
 
+// compileSelectorRegexp compiles regexp of interface names selector flag. Nil is returned, when expr is empty.
+func compileSelectorRegexp(flag string, expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("--%s: %w", flag, err)
+	}
+	return re, nil
+}
+
+// newLogger returns logger writing to Environment.Stderr, or to Environment.LogCore, if it is set.
+func newLogger(env *Environment, level zapcore.Level) *zap.SugaredLogger {
+	core := env.LogCore
+	if core == nil {
+		encConf := zap.NewDevelopmentEncoderConfig()
+		encConf.TimeKey = ""
+		core = zapcore.NewCore(
+			zapcore.NewConsoleEncoder(encConf),
+			zapcore.AddSync(env.Stderr),
+			level,
+		)
+	}
+	return zap.New(core).Sugar()
+}
+
+// defaultCacheDir returns generation cache dir in user cache dir, or empty string, if there is no such.
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gmg")
+}
+
 func handleError(env *Environment, err error) int {
 	if err == nil {
 		return 0
@@ -231,4 +492,35 @@ func handleError(env *Environment, err error) int {
 	return 1
 }
 
-const placeHolder = "{}"
+const (
+	placeHolder = "{}"
+	// defaultPackageNameTemplate is used, when --pkg is not set, and destination package is not exist.
+	defaultPackageNameTemplate = "mocks_{}"
+	// stdoutDestination is --dst value, that means output to Environment.Stdout.
+	stdoutDestination = "-"
+)
+
+func loadTemplate(env *Environment, templatePath string) (*template.Template, error) {
+	data, err := afero.ReadFile(env.fs(), templatePath)
+	if err != nil {
+		return nil, err
+	}
+	return gmg.ParseTemplate(filepath.Base(templatePath), string(data))
+}
+
+func isKnownKind(kind string) bool {
+	for _, k := range gmg.Kinds() {
+		if k.String() == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func kindsList() string {
+	var kinds []string
+	for _, k := range gmg.Kinds() {
+		kinds = append(kinds, k.String())
+	}
+	return strings.Join(kinds, ", ")
+}
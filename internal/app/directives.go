@@ -0,0 +1,205 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/skipor/gmg/pkg/gmg"
+)
+
+const generateSubcommand = "generate"
+
+// generateDirectives runs all '//go:generate gmg' directives of packages in one process, like 'go generate' would do.
+// Packages are loaded once, and shared between directives, instead of loading them in every gmg process.
+// Packages, that are written by a directive, are loaded again by following directives, so they see written files.
+func generateDirectives(ctx context.Context, env *Environment, args []string) error {
+	fs := pflag.NewFlagSet("gmg generate", pflag.ContinueOnError)
+	fs.Usage = func() {
+		b := &bytes.Buffer{}
+		p := func(format string, a ...interface{}) { _, _ = fmt.Fprintf(b, format, a...) }
+		p("Usage: gmg generate [<package pattern> ...]\n\n")
+		p("Runs all '//go:generate gmg ...' directives in files of packages, like 'go generate' does, but in one process.\n")
+		p("Packages are loaded once, and shared between directives, so that is much faster than gmg process per directive.\n")
+		p("Packages, that files are written to by a directive, are loaded again by following directives, that use them.\n")
+		p("Current directory package by default. Use './...' to run directives of all packages in module.\n")
+		p("Other directives are ignored, so run 'go generate' too, if there are ones.\n\n")
+		p("Flags:\n%s", fs.FlagUsages())
+		_, _ = b.WriteTo(env.Stderr)
+	}
+	var debug bool
+	fs.BoolVar(&debug, "debug", env.Getenv("GMG_DEBUG") != "", "Verbose debug logging.")
+	err := fs.Parse(args)
+	if err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return errExitZero
+		}
+		return fmt.Errorf("flags parse: %w", err)
+	}
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	level := zapcore.WarnLevel
+	if debug {
+		level = zapcore.DebugLevel
+	}
+	log := newLogger(env, level)
+	loader, err := newPackageLoader(ctx, log, env, patterns...)
+	if err != nil {
+		return fmt.Errorf("packages load failed: %w", err)
+	}
+	directives, err := findDirectives(log, env, loader)
+	if err != nil {
+		return err
+	}
+	var failed int
+	for _, d := range directives {
+		_, _ = fmt.Fprintf(env.Stderr, "%s:%v: %s\n", d.relPath(env), d.goGenEnv.GOLINE, gmg.JoinCommand(append([]string{"gmg"}, d.args...)))
+		err := runDirective(ctx, env, loader, d, debug)
+		if err != nil {
+			failed++
+			_, _ = fmt.Fprintf(env.Stderr, "ERROR: %s:%v: %+v\n", d.relPath(env), d.goGenEnv.GOLINE, err)
+		}
+	}
+	if failed != 0 {
+		return fmt.Errorf("%v of %v directives failed", failed, len(directives))
+	}
+	return nil
+}
+
+// directive is '//go:generate gmg' comment.
+type directive struct {
+	// dir is absolute path of directive file dir.
+	dir      string
+	goGenEnv goGenerateEnv
+	// args are gmg args with environment variables expanded.
+	args []string
+}
+
+func (d directive) relPath(env *Environment) string {
+	path := filepath.Join(d.dir, d.goGenEnv.GOFILE)
+	if rel, err := filepath.Rel(env.Dir, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+func runDirective(ctx context.Context, env *Environment, loader *packageLoader, d directive, debug bool) error {
+	dirEnv := *env
+	dirEnv.Dir = d.dir
+	dirEnv.Env = append(withoutGoGenerateEnv(env.Env),
+		"GOFILE="+d.goGenEnv.GOFILE,
+		"GOLINE="+strconv.Itoa(d.goGenEnv.GOLINE),
+		"GOPACKAGE="+d.goGenEnv.GOPACKAGE,
+	)
+	dirEnv.Args = append([]string{}, d.args...)
+	if debug {
+		dirEnv.Args = append(dirEnv.Args, "--debug")
+	}
+	params, err := loadParams(&dirEnv)
+	if err != nil {
+		return err
+	}
+	params.Loader = loader
+	return run(ctx, &dirEnv, params)
+}
+
+// findDirectives returns directives of loaded packages files, in order of packages dirs, files and lines.
+func findDirectives(log *zap.SugaredLogger, env *Environment, loader *packageLoader) ([]directive, error) {
+	var directives []directive
+	visited := map[string]bool{}
+	for _, group := range groupPackagesByDir(log, loader.all) {
+		for _, pkg := range group.pkgs {
+			// Test package files include primary package files.
+			for _, path := range pkg.GoFiles {
+				if visited[path] {
+					continue
+				}
+				visited[path] = true
+				fileDirectives, err := readDirectives(env, path, pkg.Name)
+				if err != nil {
+					return nil, fmt.Errorf("file %s: %w", path, err)
+				}
+				directives = append(directives, fileDirectives...)
+			}
+		}
+	}
+	return directives, nil
+}
+
+func readDirectives(env *Environment, path string, packageName string) ([]directive, error) {
+	content, err := afero.ReadFile(env.fs(), path)
+	if err != nil {
+		return nil, err
+	}
+	var directives []directive
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; s.Scan(); line++ {
+		args, ok := parseDirective(s.Text())
+		if !ok {
+			continue
+		}
+		goGenEnv := goGenerateEnv{
+			GOLINE:    line,
+			GOFILE:    filepath.Base(path),
+			GOPACKAGE: packageName,
+		}
+		// Like 'go generate', environment variables are expanded in every arg.
+		for i, arg := range args {
+			args[i] = os.Expand(arg, func(key string) string {
+				switch key {
+				case "GOFILE":
+					return goGenEnv.GOFILE
+				case "GOLINE":
+					return strconv.Itoa(goGenEnv.GOLINE)
+				case "GOPACKAGE":
+					return goGenEnv.GOPACKAGE
+				case "DOLLAR":
+					return "$"
+				}
+				return env.Getenv(key)
+			})
+		}
+		directives = append(directives, directive{
+			dir:      filepath.Dir(path),
+			goGenEnv: goGenEnv,
+			args:     args,
+		})
+	}
+	return directives, s.Err()
+}
+
+// parseDirective returns gmg args, if line is '//go:generate gmg' directive.
+// Args are split like 'go generate' does, see gmg.SplitCommand.
+func parseDirective(line string) ([]string, bool) {
+	const prefix = "//go:generate"
+	if !strings.HasPrefix(line, prefix) {
+		return nil, false
+	}
+	line = line[len(prefix):]
+	if line == "" || (line[0] != ' ' && line[0] != '\t') {
+		return nil, false
+	}
+	words, ok := gmg.SplitCommand(line)
+	if !ok {
+		// 'go generate' fails on that, so it is not valid directive.
+		return nil, false
+	}
+	if len(words) == 0 || words[0] != "gmg" {
+		return nil, false
+	}
+	return words[1:], true
+}
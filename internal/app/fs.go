@@ -0,0 +1,114 @@
+package app
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// fs returns Environment.Fs, that resolves relative paths against Environment.Dir, instead of process working directory.
+func (e *Environment) fs() afero.Fs {
+	return &workDirFs{Fs: e.Fs, dir: e.Dir}
+}
+
+// abs returns absolute path, resolving relative path against Environment.Dir.
+func (e *Environment) abs(path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(e.Dir, path)
+}
+
+// overlay returns Go files of passed dirs read from Environment.Fs, to be passed as packages.Config.Overlay.
+// That is, Go tooling sees sources, that may be not on disk.
+// Directories that are not local paths (import paths, for example) are ignored.
+// Nil is returned for OS file system, as Go tooling reads the same files itself.
+func (e *Environment) overlay(dirs ...string) (map[string][]byte, error) {
+	if _, ok := e.Fs.(*afero.OsFs); ok {
+		return nil, nil
+	}
+	fs := e.fs()
+	overlay := map[string][]byte{}
+	for _, dir := range dirs {
+		if !build.IsLocalImport(dir) && !filepath.IsAbs(dir) {
+			continue
+		}
+		dir = e.abs(dir)
+		infos, err := afero.ReadDir(fs, dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			if info.IsDir() || !strings.HasSuffix(info.Name(), ".go") {
+				continue
+			}
+			filePath := filepath.Join(dir, info.Name())
+			data, err := afero.ReadFile(fs, filePath)
+			if err != nil {
+				return nil, err
+			}
+			overlay[filePath] = data
+		}
+	}
+	return overlay, nil
+}
+
+// workDirFs resolves relative paths against dir.
+type workDirFs struct {
+	afero.Fs
+	dir string
+}
+
+var _ afero.Fs = &workDirFs{}
+
+func (fs *workDirFs) abs(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(fs.dir, name)
+}
+
+func (fs *workDirFs) Create(name string) (afero.File, error) { return fs.Fs.Create(fs.abs(name)) }
+
+func (fs *workDirFs) Mkdir(name string, perm os.FileMode) error {
+	return fs.Fs.Mkdir(fs.abs(name), perm)
+}
+
+func (fs *workDirFs) MkdirAll(path string, perm os.FileMode) error {
+	return fs.Fs.MkdirAll(fs.abs(path), perm)
+}
+
+func (fs *workDirFs) Open(name string) (afero.File, error) { return fs.Fs.Open(fs.abs(name)) }
+
+func (fs *workDirFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	return fs.Fs.OpenFile(fs.abs(name), flag, perm)
+}
+
+func (fs *workDirFs) Remove(name string) error { return fs.Fs.Remove(fs.abs(name)) }
+
+func (fs *workDirFs) RemoveAll(path string) error { return fs.Fs.RemoveAll(fs.abs(path)) }
+
+func (fs *workDirFs) Rename(oldname, newname string) error {
+	return fs.Fs.Rename(fs.abs(oldname), fs.abs(newname))
+}
+
+func (fs *workDirFs) Stat(name string) (os.FileInfo, error) { return fs.Fs.Stat(fs.abs(name)) }
+
+func (fs *workDirFs) Chmod(name string, mode os.FileMode) error {
+	return fs.Fs.Chmod(fs.abs(name), mode)
+}
+
+func (fs *workDirFs) Chown(name string, uid, gid int) error {
+	return fs.Fs.Chown(fs.abs(name), uid, gid)
+}
+
+func (fs *workDirFs) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	return fs.Fs.Chtimes(fs.abs(name), atime, mtime)
+}
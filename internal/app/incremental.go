@@ -0,0 +1,124 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/skipor/gmg/pkg/gmg"
+	"github.com/skipor/gmg/pkg/gogen"
+)
+
+// previouslyGenerated compares interfaces with ones that existing file was generated from.
+// Existing file is returned, when it was generated by the same command for the same required Go version from interfaces
+// with the same method sets, so it doesn't need to be rendered again. Otherwise, nil file is returned.
+// File should be also not older than gmg executable, as generated code may change with gmg update,
+// and not older than go.mod, as its go directive decides which language features are used,
+// and its code should not be edited after generation, which is detected by body hash recorded in header.
+// Files are never skipped on --check, as it should report any difference.
+// Interfaces, which method set hash differs from recorded in header, are returned as changed.
+func previouslyGenerated(env *Environment, params *params, filePath string, cmd []string, requiredGoVersion string, ifaces []gmg.Interface) (*gogen.File, []string) {
+	if params.Destination == stdoutDestination {
+		return nil, nil
+	}
+	content, err := afero.ReadFile(env.fs(), filePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			params.Log.Debugf("File %s read failed, so it will be generated: %s", filePath, err)
+		}
+		return nil, nil
+	}
+	header, ok := gmg.ParseHeader(content)
+	if !ok {
+		return nil, nil
+	}
+	prevHashes := map[gmg.Source]string{}
+	for _, src := range header.Sources {
+		hash := src.Hash
+		src.Hash = ""
+		prevHashes[src] = hash
+	}
+	var changed []string
+	sameSources := len(header.Sources) == len(ifaces)
+	for _, iface := range ifaces {
+		src := gmg.Source{ImportPath: iface.ImportPath, Name: iface.Name}
+		prevHash, ok := prevHashes[src]
+		if !ok || prevHash == "" {
+			sameSources = false
+			continue
+		}
+		if prevHash != iface.MethodSetHash() {
+			changed = append(changed, src.String())
+		}
+	}
+	// Only mocks are generated from method sets only. Other kinds depend on comments, and template may be changed.
+	canSkip := !params.NoIncremental && !params.Check && params.Template == nil && params.Kind == gmg.KindMock && !hasMockNames(ifaces)
+	upToDate := canSkip && sameSources && len(changed) == 0 &&
+		header.Requires == requiredGoVersion &&
+		strings.Join(header.Command, "\x00") == strings.Join(cmd, "\x00")
+	if !upToDate {
+		return nil, changed
+	}
+	if header.BodyHash != gmg.BodyHash(content) {
+		params.Log.Debugf("File %s was edited after generation, so it will be rendered again", filePath)
+		return nil, changed
+	}
+	if olderThanExecutable(env, filePath) {
+		params.Log.Debugf("File %s is older than gmg executable, so it will be rendered again", filePath)
+		return nil, changed
+	}
+	if olderThanGoMod(env, filePath) {
+		params.Log.Debugf("File %s is older than go.mod, which go directive may change generated code, so it will be rendered again", filePath)
+		return nil, changed
+	}
+	params.Log.Debugf("File %s is up to date, as interfaces method sets are not changed", filePath)
+	return gogen.NewRenderedFile(filePath, content), nil
+}
+
+// olderThanExecutable returns true, when file was modified before gmg executable, or any of them can't be stat.
+func olderThanExecutable(env *Environment, filePath string) bool {
+	exe, err := os.Executable()
+	if err != nil {
+		return true
+	}
+	exeInfo, err := os.Stat(exe)
+	if err != nil {
+		return true
+	}
+	info, err := env.fs().Stat(filePath)
+	if err != nil {
+		return true
+	}
+	return info.ModTime().Before(exeInfo.ModTime())
+}
+
+// olderThanGoMod returns true, when file was modified before go.mod of its module, or any of them can't be stat.
+// False is returned, when file is not in module.
+func olderThanGoMod(env *Environment, filePath string) bool {
+	m, ok := findModule(env, filepath.Dir(filePath))
+	if !ok {
+		return false
+	}
+	goModInfo, err := env.fs().Stat(filepath.Join(m.Dir, "go.mod"))
+	if err != nil {
+		return true
+	}
+	info, err := env.fs().Stat(filePath)
+	if err != nil {
+		return true
+	}
+	return !info.ModTime().After(goModInfo.ModTime())
+}
+
+// hasMockNames returns true, when some interface mock name is set by annotation.
+// Such files are always rendered, as mock name is not recorded in header.
+func hasMockNames(ifaces []gmg.Interface) bool {
+	for _, iface := range ifaces {
+		if iface.MockName != "" {
+			return true
+		}
+	}
+	return false
+}
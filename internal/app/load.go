@@ -2,31 +2,44 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"go.uber.org/zap"
 	"golang.org/x/tools/go/packages"
 )
 
-func loadPackages(log *zap.SugaredLogger, env *Environment, src string) ([]*packages.Package, error) {
-	log.Debugf("Loading package: %s", src)
+// loadPackages loads packages matched by patterns. Test packages are loaded only when tests is set,
+// as that requires test files type check, and extra go list work.
+func loadPackages(ctx context.Context, log *zap.SugaredLogger, env *Environment, tests bool, patterns ...string) ([]*packages.Package, error) {
+	log.Debugf("Loading packages (tests: %v): %s", tests, strings.Join(patterns, " "))
+	overlay, err := env.overlay(patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("read sources: %w", err)
+	}
 	pkgs, err := packages.Load(&packages.Config{
+		Context: ctx,
 		Mode: packages.NeedName | packages.NeedTypes | packages.NeedModule |
-			// Workaround to fix "Unexpected package creation during export data loading".
-			// See https://github.com/golang/go/issues/45218.
+			// Types need import graph anyway, and imports are used to track changes of module packages.
 			packages.NeedImports |
-			// Print files in debug, to see what files where loaded.
-			packages.NeedFiles | packages.NeedCompiledGoFiles,
+			// Files are needed to find package dir, and GOFILE of 'go generate' call.
+			// Compiled files are not, as they differ from source ones only for cgo packages.
+			packages.NeedFiles,
 		Dir:        env.Dir,
 		Env:        env.Env,
-		Tests:      true,
+		Overlay:    overlay,
+		Tests:      tests,
 		BuildFlags: nil, // TODO(skipor)
-	}, src)
+	}, patterns...)
 	if err != nil {
 		return nil, err
 	}
 	pkgs = packagesWithoutTestExecutable(pkgs)
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no packages matched")
+	}
 	debugLogPkgs(log, pkgs)
 
 	loadFailed := len(pkgs) == 1 && pkgs[0].Name == ""
@@ -34,10 +47,7 @@ func loadPackages(log *zap.SugaredLogger, env *Environment, src string) ([]*pack
 		return nil, &loadErrs{pkgs[0].Errors}
 	}
 
-	if errNum := packagesErrorsNum(pkgs); errNum != 0 {
-		str := printPackagesErrors(pkgs)
-		log.Warnf("Packages loaded with %v errors. Generation may fail, if type information was not able to load.\n%s", errNum, str)
-	}
+	warnPackagesErrors(log, pkgs)
 	return pkgs, nil
 }
 
@@ -53,7 +63,7 @@ func debugLogPkgs(log *zap.SugaredLogger, pkgs []*packages.Package) {
 		p("- ID: %s\n", pkg.ID)
 		p("  Name: %s\n", pkg.Name)
 		p("  PkgPath: %s\n", pkg.PkgPath)
-		p("  Compiled files: %s\n", pkg.CompiledGoFiles)
+		p("  Files: %s\n", pkg.GoFiles)
 		p("  Ignored files: %s\n", pkg.IgnoredFiles)
 		if m := pkg.Module; m != nil {
 			p("  Module:\n")
@@ -103,3 +113,144 @@ func (e *loadErrs) Error() string {
 	}
 	return buf.String()
 }
+
+// needTestPackages returns true, when selected interfaces may be declared in test packages.
+// Interfaces selected by names are searched in test packages only if they are not found in primary package,
+// so test packages are loaded on demand for them. See missingInterfaceNames.
+func needTestPackages(params *params) bool {
+	sel := params.Selector
+	switch {
+	case params.Prune:
+		// Files generated from test packages interfaces are recognized as generated from source package by them.
+		return true
+	case len(sel.names) != 0:
+		return false
+	case sel.all || sel.annotated:
+		return sel.packageKind != primaryPackageKind
+	}
+	// Interfaces of 'go generate' file package are selected, and it may be test or black-box test package.
+	return sel.goGenEnv.packageKind() != primaryPackageKind
+}
+
+// missingInterfaceNames returns names, that are not declared in loaded packages.
+func missingInterfaceNames(pkgs []*packages.Package, names []string) []string {
+	var missing []string
+	for _, name := range names {
+		found := false
+		for _, pkg := range pkgs {
+			if pkg.Types != nil && pkg.Types.Scope().Lookup(name) != nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// sourceLoader loads source packages, sharing them between many runs in one process.
+type sourceLoader interface {
+	// load returns packages of src, that is resolved relative to Environment.Dir.
+	// Test packages are returned, when tests is set. Otherwise, they may be returned or not.
+	load(ctx context.Context, log *zap.SugaredLogger, env *Environment, src string, tests bool) ([]*packages.Package, error)
+	// loaded returns all already loaded packages, to find destination package among them. May return nil.
+	loaded() []*packages.Package
+	// written is called with paths of written and deleted files, so loader may drop packages, that became stale.
+	written(log *zap.SugaredLogger, paths []string)
+}
+
+// packageLoader loads packages once, and shares them between many runs in one process.
+// Packages, that files are written to by a run, and packages importing them, are loaded again by following runs.
+// Packages matched by initial patterns are found by dir and import path.
+// Other sources are loaded on demand, and remembered too.
+// Test packages are always loaded, as directives may be in test files of any loaded package.
+type packageLoader struct {
+	all      []*packages.Package
+	bySource map[string][]*packages.Package
+}
+
+var _ sourceLoader = &packageLoader{}
+
+func newPackageLoader(ctx context.Context, log *zap.SugaredLogger, env *Environment, patterns ...string) (*packageLoader, error) {
+	pkgs, err := loadPackages(ctx, log, env, true, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	l := &packageLoader{all: pkgs, bySource: map[string][]*packages.Package{}}
+	for _, group := range groupPackagesByDir(log, pkgs) {
+		l.bySource[group.dir] = group.pkgs
+		if primary := getPackageByKind(group.pkgs, primaryPackageKind); primary != nil {
+			l.bySource[primary.PkgPath] = group.pkgs
+		}
+	}
+	return l, nil
+}
+
+// load loads packages of src, only if they were not loaded before.
+func (l *packageLoader) load(ctx context.Context, log *zap.SugaredLogger, env *Environment, src string, _ bool) ([]*packages.Package, error) {
+	key := src
+	if isLocalSource(src) {
+		key = env.abs(src)
+	}
+	if pkgs, ok := l.bySource[key]; ok {
+		log.Debugf("Package %s is already loaded", src)
+		return pkgs, nil
+	}
+	pkgs, err := loadPackages(ctx, log, env, true, src)
+	if err != nil {
+		return nil, err
+	}
+	l.all = append(l.all, pkgs...)
+	l.bySource[key] = pkgs
+	return pkgs, nil
+}
+
+func (l *packageLoader) loaded() []*packages.Package { return l.all }
+
+// written drops packages of written files dirs, and packages importing them, so they are loaded again on demand.
+func (l *packageLoader) written(log *zap.SugaredLogger, paths []string) {
+	dirs := map[string]bool{}
+	for _, path := range paths {
+		dirs[filepath.Dir(path)] = true
+	}
+	stale := map[string]bool{}
+	for _, pkg := range l.all {
+		if dirs[packageDir(pkg)] {
+			stale[pkg.PkgPath] = true
+		}
+	}
+	if len(stale) == 0 {
+		return
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, pkg := range l.all {
+			if stale[pkg.PkgPath] {
+				continue
+			}
+			for importPath := range pkg.Imports {
+				if stale[importPath] {
+					stale[pkg.PkgPath] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	all := l.all[:0]
+	for _, pkg := range l.all {
+		if stale[pkg.PkgPath] {
+			log.Debugf("Package %s is dropped, as its files or dependencies are written", pkg.ID)
+			continue
+		}
+		all = append(all, pkg)
+	}
+	l.all = all
+	for key, pkgs := range l.bySource {
+		if len(pkgs) != 0 && stale[pkgs[0].PkgPath] {
+			delete(l.bySource, key)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/packages"
+)
+
+// module is Go module, found by go.mod file.
+type module struct {
+	// Path is module path from go.mod.
+	Path string
+	// Dir is absolute path of module root dir.
+	Dir string
+	// GoVersion is version from go directive of go.mod. Empty, if there is no go directive.
+	GoVersion string
+}
+
+// findModule returns module, that contains dir.
+// False is returned, if there is no such module.
+func findModule(env *Environment, dir string) (module, bool) {
+	dir = env.abs(dir)
+	for modDir := dir; ; modDir = filepath.Dir(modDir) {
+		data, err := afero.ReadFile(env.fs(), filepath.Join(modDir, "go.mod"))
+		if err == nil {
+			m := module{Path: modfile.ModulePath(data), Dir: modDir}
+			if f, err := modfile.ParseLax(filepath.Join(modDir, "go.mod"), data, nil); err == nil && f.Go != nil {
+				m.GoVersion = f.Go.Version
+			}
+			return m, m.Path != ""
+		}
+		if !os.IsNotExist(err) || filepath.Dir(modDir) == modDir {
+			return module{}, false
+		}
+	}
+}
+
+// dirImportPath returns import path of package in dir, deduced from go.mod of module that contains dir.
+// False is returned, if there is no such module.
+func dirImportPath(env *Environment, dir string) (string, bool) {
+	m, ok := findModule(env, dir)
+	if !ok {
+		return "", false
+	}
+	rel, err := filepath.Rel(m.Dir, env.abs(dir))
+	if err != nil {
+		return "", false
+	}
+	return path.Join(m.Path, filepath.ToSlash(rel)), true
+}
+
+// importPathDir returns dir of package with import path, if package is in module.
+func (m module) importPathDir(importPath string) (string, bool) {
+	if importPath != m.Path && !strings.HasPrefix(importPath, m.Path+"/") {
+		return "", false
+	}
+	return filepath.Join(m.Dir, filepath.FromSlash(strings.TrimPrefix(importPath, m.Path))), true
+}
+
+// goVersion returns go directive version of module, that contains dir.
+// When dir is not in module, go version of fallback package module is returned.
+// Empty string is returned, when version is unknown.
+func goVersion(env *Environment, dir string, fallback *packages.Package) string {
+	if m, ok := findModule(env, dir); ok {
+		return m.GoVersion
+	}
+	if fallback != nil && fallback.Module != nil {
+		return fallback.Module.GoVersion
+	}
+	return ""
+}
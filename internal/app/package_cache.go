@@ -0,0 +1,245 @@
+package app
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/tools/go/packages"
+)
+
+// packageCache is sourceLoader, that keeps loaded packages between runs, until their files are changed.
+// Files are compared by modification time and size, and dirs by list of Go files.
+// Tracked are files and dirs of loaded packages, go.mod and go.sum of their module,
+// and dirs of packages of the same module, that are imported transitively.
+// Changes in other modules are not tracked, but they usually come with go.mod or go.sum change.
+// At most maxPackageCacheEntries are kept, least recently used are evicted.
+// It is safe for concurrent use. Packages are loaded without lock, so concurrent runs don't wait each other.
+type packageCache struct {
+	mu      sync.Mutex
+	entries map[packageCacheKey]*packageCacheEntry
+	// uses is incremented on every load, to find least recently used entry.
+	uses uint64
+}
+
+// maxPackageCacheEntries bounds server memory, as every entry keeps type information of loaded packages.
+const maxPackageCacheEntries = 64
+
+var _ sourceLoader = &packageCache{}
+
+func newPackageCache() *packageCache {
+	return &packageCache{entries: map[packageCacheKey]*packageCacheEntry{}}
+}
+
+type packageCacheKey struct {
+	// dir is Environment.Dir, that import path is resolved from. Empty for local sources.
+	dir string
+	// src is absolute path for local sources, or import path otherwise.
+	src string
+	// env is joined Environment.Env, as it affects build.
+	env string
+	// tests is set, when test packages are loaded.
+	tests bool
+}
+
+type packageCacheEntry struct {
+	pkgs []*packages.Package
+	// used is packageCache.uses value of last load.
+	used   uint64
+	stamps map[string]fileStamp
+	// dirs are Go file names of dirs.
+	dirs map[string]string
+}
+
+func (c *packageCache) load(ctx context.Context, log *zap.SugaredLogger, env *Environment, src string, tests bool) ([]*packages.Package, error) {
+	key := packageCacheKey{
+		dir: env.Dir,
+		src: src,
+		// Variables set by 'go generate' differ for every directive, but don't affect load.
+		env:   strings.Join(withoutGoGenerateEnv(env.Env), "\x00"),
+		tests: tests,
+	}
+	if isLocalSource(src) {
+		key.dir, key.src = "", env.abs(src)
+	}
+	c.mu.Lock()
+	c.uses++
+	entry, ok := c.entries[key]
+	if ok {
+		entry.used = c.uses
+	}
+	c.mu.Unlock()
+	if ok {
+		changed := entry.changed()
+		if changed == "" {
+			log.Debugf("Package %s is loaded from server cache", src)
+			return entry.pkgs, nil
+		}
+		log.Debugf("Package %s server cache is invalidated, as %s is changed", src, changed)
+	}
+	pkgs, err := loadPackages(ctx, log, env, tests, src)
+	if err != nil {
+		return nil, err
+	}
+	entry = newPackageCacheEntry(pkgs)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxPackageCacheEntries {
+		c.evict(log)
+	}
+	entry.used = c.uses
+	c.entries[key] = entry
+	return pkgs, nil
+}
+
+// evict removes least recently used entry. Called under lock.
+func (c *packageCache) evict(log *zap.SugaredLogger) {
+	var oldest packageCacheKey
+	var oldestUsed uint64
+	for key, entry := range c.entries {
+		if oldestUsed == 0 || entry.used < oldestUsed {
+			oldest, oldestUsed = key, entry.used
+		}
+	}
+	log.Debugf("Package %s is evicted from server cache", oldest.src)
+	delete(c.entries, oldest)
+}
+
+// loaded returns nil, as cached packages may be stale.
+func (c *packageCache) loaded() []*packages.Package { return nil }
+
+// written does nothing, as changed files are detected on load.
+func (c *packageCache) written(*zap.SugaredLogger, []string) {}
+
+// newPackageCacheEntry remembers state of files and dirs, which change should invalidate loaded packages.
+func newPackageCacheEntry(pkgs []*packages.Package) *packageCacheEntry {
+	e := &packageCacheEntry{pkgs: pkgs, stamps: map[string]fileStamp{}, dirs: map[string]string{}}
+	addDir := func(dir string) {
+		if _, ok := e.dirs[dir]; ok {
+			return
+		}
+		e.dirs[dir] = dirGoFiles(dir)
+		for _, name := range strings.Fields(e.dirs[dir]) {
+			path := filepath.Join(dir, name)
+			e.stamps[path] = stampFile(path)
+		}
+	}
+	for _, pkg := range pkgs {
+		for _, files := range [][]string{pkg.GoFiles, pkg.OtherFiles, pkg.IgnoredFiles} {
+			for _, path := range files {
+				e.stamps[path] = stampFile(path)
+			}
+		}
+		if dir := packageDir(pkg); dir != "" {
+			addDir(dir)
+		}
+		m := pkg.Module
+		if m == nil {
+			continue
+		}
+		if m.GoMod != "" {
+			for _, path := range []string{m.GoMod, filepath.Join(filepath.Dir(m.GoMod), "go.sum")} {
+				e.stamps[path] = stampFile(path)
+			}
+		}
+		mod := module{Path: m.Path, Dir: m.Dir}
+		var queue []string
+		for importPath := range pkg.Imports {
+			if dir, ok := mod.importPathDir(importPath); ok {
+				queue = append(queue, dir)
+			}
+		}
+		// Imported packages are loaded without deps, so their imports are parsed from files.
+		for len(queue) != 0 {
+			dir := queue[0]
+			queue = queue[1:]
+			if _, ok := e.dirs[dir]; ok {
+				continue
+			}
+			addDir(dir)
+			for _, importPath := range dirImports(dir) {
+				if importDir, ok := mod.importPathDir(importPath); ok {
+					queue = append(queue, importDir)
+				}
+			}
+		}
+	}
+	return e
+}
+
+// dirImports returns import paths of non-test Go files in dir.
+func dirImports(dir string) []string {
+	var imports []string
+	for _, name := range strings.Fields(dirGoFiles(dir)) {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, _ := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, parser.ImportsOnly)
+		if file == nil {
+			continue
+		}
+		for _, imp := range file.Imports {
+			if importPath, err := strconv.Unquote(imp.Path.Value); err == nil {
+				imports = append(imports, importPath)
+			}
+		}
+	}
+	return imports
+}
+
+// changed returns path of first tracked file or dir, that was changed, or empty string.
+func (e *packageCacheEntry) changed() string {
+	for _, path := range sortedKeys(e.dirs) {
+		if dirGoFiles(path) != e.dirs[path] {
+			return path
+		}
+	}
+	for _, path := range sortedKeys(e.stamps) {
+		if stampFile(path) != e.stamps[path] {
+			return path
+		}
+	}
+	return ""
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// dirGoFiles returns space separated sorted names of Go files in dir.
+func dirGoFiles(dir string) string {
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	names := make([]string, len(paths))
+	for i, path := range paths {
+		names[i] = filepath.Base(path)
+	}
+	return strings.Join(names, " ")
+}
+
+// fileStamp is file state, that changes on file modification.
+type fileStamp struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+func stampFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{exists: true, modTime: info.ModTime(), size: info.Size()}
+}
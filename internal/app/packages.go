@@ -1,8 +1,12 @@
 package app
 
 import (
+	"go/build"
+	"path/filepath"
+	"sort"
 	"strings"
 
+	"go.uber.org/zap"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -21,8 +25,19 @@ const (
 	// testExecutablePackageKind is virtual package from test executable files, that are generated during 'go test' rung.
 	// ID is like 'pkg.test'
 	testExecutablePackageKind = "test-executable"
+	// anyPackageKind is not package kind, but --package-kind value, that selects primary, test and black-box test packages.
+	anyPackageKind = "any"
 )
 
+// isSelectablePackageKind returns true for --package-kind values.
+func isSelectablePackageKind(k string) bool {
+	switch k {
+	case primaryPackageKind, testPackageKind, blackBoxTestPackageKind, anyPackageKind:
+		return true
+	}
+	return false
+}
+
 func getPackageKind(p *packages.Package) packageKind {
 	if strings.HasSuffix(p.ID, ".test") {
 		return testExecutablePackageKind
@@ -48,12 +63,62 @@ func getPackageByKind(pkgs []*packages.Package, k packageKind) *packages.Package
 func (k packageKind) String() string { return string(k) }
 
 func packagesWithoutTestExecutable(pkgs []*packages.Package) []*packages.Package {
-	for i, pkg := range pkgs {
-		if getPackageKind(pkg) == testExecutablePackageKind {
-			return append(pkgs[:i], pkgs[i+1:]...)
+	var filtered []*packages.Package
+	for _, pkg := range pkgs {
+		if getPackageKind(pkg) != testExecutablePackageKind {
+			filtered = append(filtered, pkg)
+		}
+	}
+	return filtered
+}
+
+// isRecursivePattern returns true for package patterns like './...', that match many packages.
+func isRecursivePattern(src string) bool {
+	return strings.HasSuffix(src, "...")
+}
+
+// isLocalSource returns true for source, that is relative or absolute path, but not import path.
+func isLocalSource(src string) bool {
+	return build.IsLocalImport(src) || filepath.IsAbs(src)
+}
+
+// packageDir returns absolute path of package directory, or empty string if package has no files.
+func packageDir(pkg *packages.Package) string {
+	for _, files := range [][]string{pkg.GoFiles, pkg.CompiledGoFiles, pkg.IgnoredFiles} {
+		if len(files) != 0 {
+			return filepath.Dir(files[0])
+		}
+	}
+	return ""
+}
+
+// packageGroup is primary package and its test packages, that are located in dir.
+type packageGroup struct {
+	dir  string
+	pkgs []*packages.Package
+}
+
+// groupPackagesByDir groups packages loaded by recursive pattern, sorted by dir.
+// Packages in group are in load order, so primary package goes first, like in case of single package load.
+func groupPackagesByDir(log *zap.SugaredLogger, pkgs []*packages.Package) []*packageGroup {
+	byDir := map[string]*packageGroup{}
+	var groups []*packageGroup
+	for _, pkg := range pkgs {
+		dir := packageDir(pkg)
+		if dir == "" || pkg.Name == "" {
+			log.Debugf("Skipping package %s without files", pkg.ID)
+			continue
+		}
+		group, ok := byDir[dir]
+		if !ok {
+			group = &packageGroup{dir: dir}
+			byDir[dir] = group
+			groups = append(groups, group)
 		}
+		group.pkgs = append(group.pkgs, pkg)
 	}
-	return pkgs
+	sort.Slice(groups, func(i, j int) bool { return groups[i].dir < groups[j].dir })
+	return groups
 }
 
 func packagesErrorsNum(pkgs []*packages.Package) int {
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"go.uber.org/zap"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/skipor/gmg/pkg/gmg"
+	"github.com/skipor/gmg/pkg/gogen"
+)
+
+// staleFiles returns files in dstDir, that were generated by gmg from interfaces of pkgs, but not selected anymore.
+// With --all-file only files generated from not existing interfaces are stale, as interfaces from other files are not selected,
+// but they may be generated by other '//go:generate gmg' comments.
+func staleFiles(log *zap.SugaredLogger, env *Environment, dstDir string, files []*gogen.File, pkgs []*packages.Package, selected []gmg.Interface, sel interfaceSelector) ([]string, error) {
+	infos, err := afero.ReadDir(env.fs(), dstDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	isGenerated := map[string]bool{}
+	for _, f := range files {
+		isGenerated[f.Path()] = true
+	}
+	isSelected := map[gmg.Source]bool{}
+	for _, iface := range selected {
+		isSelected[gmg.Source{ImportPath: iface.ImportPath, Name: iface.Name}] = true
+	}
+	sourcePkgs := map[string]*packages.Package{}
+	for _, pkg := range pkgs {
+		sourcePkgs[pkg.PkgPath] = pkg
+	}
+	isStale := func(source gmg.Source) bool {
+		pkg, ok := sourcePkgs[source.ImportPath]
+		if !ok || isSelected[source] {
+			return false
+		}
+		if sel.allFile && pkg.Types != nil && pkg.Types.Scope().Lookup(source.Name) != nil {
+			return false
+		}
+		return true
+	}
+
+	var stale []string
+	for _, info := range infos {
+		filePath := filepath.Join(dstDir, info.Name())
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".go") || isGenerated[filePath] {
+			continue
+		}
+		content, err := afero.ReadFile(env.fs(), filePath)
+		if err != nil {
+			return nil, err
+		}
+		header, ok := gmg.ParseHeader(content)
+		if !ok || len(header.Sources) == 0 {
+			continue
+		}
+		sources := header.Sources
+		allStale := true
+		for _, source := range sources {
+			allStale = allStale && isStale(source)
+		}
+		if allStale {
+			log.Debugf("File %s is stale, as its sources are not selected: %s", filePath, sources)
+			stale = append(stale, filePath)
+		}
+	}
+	return stale, nil
+}
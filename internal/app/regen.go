@@ -0,0 +1,147 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+
+	"github.com/skipor/gmg/pkg/gmg"
+)
+
+const regenSubcommand = "regen"
+
+// regen regenerates files generated by gmg, using commands recorded in their headers.
+func regen(ctx context.Context, env *Environment, args []string) error {
+	fs := pflag.NewFlagSet("gmg regen", pflag.ContinueOnError)
+	fs.Usage = func() {
+		b := &bytes.Buffer{}
+		p := func(format string, a ...interface{}) { _, _ = fmt.Fprintf(b, format, a...) }
+		p("Usage: gmg regen [<path> ...]\n\n")
+		p("Finds files generated by gmg in paths, and regenerates them, using commands recorded in their headers.\n")
+		p("Directories are searched recursively, skipping vendor, testdata and hidden ones. Current directory by default.\n")
+		p("Files generated by gmg versions, that didn't record command, are skipped.\n\n")
+		p("Flags:\n%s", fs.FlagUsages())
+		_, _ = b.WriteTo(env.Stderr)
+	}
+	var debug bool
+	fs.BoolVar(&debug, "debug", env.Getenv("GMG_DEBUG") != "", "Verbose debug logging.")
+	err := fs.Parse(args)
+	if err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return errExitZero
+		}
+		return fmt.Errorf("flags parse: %w", err)
+	}
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	files, err := findGeneratedFiles(env, paths)
+	if err != nil {
+		return err
+	}
+	var failed int
+	for _, file := range files {
+		if len(file.header.Command) == 0 {
+			_, _ = fmt.Fprintf(env.Stderr, "skipped: %s: no command in header, regenerate it manually once\n", file.path)
+			continue
+		}
+		_, _ = fmt.Fprintf(env.Stderr, "regen: %s\n", file.path)
+		err := regenFile(ctx, env, file, debug)
+		if err != nil {
+			failed++
+			_, _ = fmt.Fprintf(env.Stderr, "ERROR: %s: %+v\n", file.path, err)
+		}
+	}
+	if failed != 0 {
+		return fmt.Errorf("%v of %v files regeneration failed", failed, len(files))
+	}
+	return nil
+}
+
+type generatedFile struct {
+	// path is relative to Environment.Dir, if passed path was relative.
+	path   string
+	header gmg.Header
+}
+
+func regenFile(ctx context.Context, env *Environment, file generatedFile, debug bool) error {
+	fileEnv := *env
+	fileEnv.Dir = env.abs(filepath.Dir(file.path))
+	fileEnv.Env = withoutGoGenerateEnv(env.Env)
+	fileEnv.Args = append([]string{}, file.header.Command...)
+	if debug {
+		fileEnv.Args = append(fileEnv.Args, "--debug")
+	}
+	params, err := loadParams(&fileEnv)
+	if err != nil {
+		return fmt.Errorf("recorded command: %w", err)
+	}
+	if params.Destination == stdoutDestination {
+		return fmt.Errorf("recorded command printed file to stdout with '--dst -', so it can't be reproduced. " +
+			"Regenerate it once with --dst set to the file path")
+	}
+	return run(ctx, &fileEnv, params)
+}
+
+// findGeneratedFiles returns files generated by gmg, sorted by path.
+func findGeneratedFiles(env *Environment, paths []string) ([]generatedFile, error) {
+	fs := env.fs()
+	var files []generatedFile
+	visit := func(path string) error {
+		content, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return err
+		}
+		header, ok := gmg.ParseHeader(content)
+		if ok {
+			files = append(files, generatedFile{path: path, header: header})
+		}
+		return nil
+	}
+	for _, root := range paths {
+		err := afero.Walk(fs, root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				name := info.Name()
+				if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+			return visit(path)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %w", root, err)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+	return files, nil
+}
+
+// withoutGoGenerateEnv returns env without variables set by 'go generate',
+// as recorded commands select interfaces explicitly.
+func withoutGoGenerateEnv(env []string) []string {
+	var filtered []string
+	for _, kv := range env {
+		switch strings.SplitN(kv, "=", 2)[0] {
+		case "GOFILE", "GOLINE", "GOPACKAGE":
+			continue
+		}
+		filtered = append(filtered, kv)
+	}
+	return filtered
+}
@@ -1,13 +1,19 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
+	"text/template"
 
 	"github.com/iancoleman/strcase"
+	"github.com/spf13/afero"
 	"go.uber.org/zap"
 	"golang.org/x/tools/go/packages"
 
@@ -23,6 +29,28 @@ type params struct {
 	Destination string
 	// Package is package name in generated files. See flag description for details.
 	Package string
+	// Kind is kind of generated code.
+	Kind gmg.Kind
+	// Template is user template of generated code, that is used instead of Kind, when set.
+	Template *template.Template
+	// TemplatePath is absolute path of Template.
+	TemplatePath string
+	// Check is set, when files should be compared with existing instead of writing.
+	Check bool
+	// Force is set, when existing not generated files may be overwritten.
+	Force bool
+	// Prune is set, when stale generated files in destination dir should be deleted.
+	Prune bool
+	// NoCache is set, when generation cache should not be used.
+	NoCache bool
+	// NoIncremental is set, when files generated from not changed interfaces should be rendered anyway.
+	NoIncremental bool
+	// Mode is the way source package is loaded.
+	Mode loadMode
+	// SharedRuntime is set, when mocks should use github.com/skipor/gmg/pkg/gmgrt call wrappers.
+	SharedRuntime bool
+	// Loader is set, when packages are shared with other runs in the same process.
+	Loader sourceLoader
 
 	Selector interfaceSelector
 }
@@ -36,113 +64,418 @@ type goGenerateEnv struct {
 	GOPACKAGE string
 }
 
-func run(env *Environment, params *params) error {
+func run(ctx context.Context, env *Environment, params *params) error {
 	log := params.Log
-	pkgs, err := loadPackages(log, env, params.Source)
-	if err != nil {
-		errStr := err.Error()
-		if strings.Contains(errStr, "\n") {
-			errStr = "\n" + errStr
-		}
-		return fmt.Errorf("package '%s' load failed: %s", params.Source, errStr)
-	}
-	primaryPkg := pkgs[0]
-	log.Infof("Processing package: %s", primaryPkg.ID)
-	var opts []gogen.Option
-	if primaryPkg.Module != nil {
-		opts = append(opts, gogen.ModulePath(primaryPkg.Module.Path))
-	}
-	files, err := generateAll(env, pkgs, params)
+	res, err := generate(ctx, env, params)
 	if err != nil {
 		return err
 	}
+	files := res.Files
 	var fileNames []string
 	for _, f := range files {
 		fileNames = append(fileNames, f.Path())
 	}
+	if params.Destination == stdoutDestination {
+		return writeStdout(env, files)
+	}
+	for _, iface := range res.Changed {
+		_, _ = fmt.Fprintf(env.Stderr, "interface changed: %s\n", iface)
+	}
+	if params.Check {
+		log.Debugf("Checking: %s", strings.Join(fileNames, ", "))
+		return checkFiles(log, env, files, res.Stale)
+	}
 	log.Debugf("Generating: %s", strings.Join(fileNames, ", "))
+	if !params.Force {
+		err := checkNotOverwritingHandWritten(env, files)
+		if err != nil {
+			return err
+		}
+	}
+	// Unchanged files are not written, so build caches and file watchers are not invalidated for nothing.
+	written, err := gogen.WriteFiles(env.fs(), files)
+	if err != nil {
+		return err
+	}
+	isWritten := map[*gogen.File]bool{}
+	for _, f := range written {
+		isWritten[f] = true
+	}
 	for _, f := range files {
-		err := f.WriteFile(env.Fs)
+		status := "unchanged"
+		if isWritten[f] {
+			status = "written"
+		}
+		_, _ = fmt.Fprintf(env.Stderr, "%s: %s\n", status, f.Path())
+	}
+	for _, path := range res.Stale {
+		err := env.fs().Remove(path)
 		if err != nil {
-			return fmt.Errorf("file %s: %w", f.Path(), err)
+			return fmt.Errorf("stale file %s: remove: %w", path, err)
 		}
-		_, _ = fmt.Fprintf(env.Stderr, "%s\n", f.Path())
+		_, _ = fmt.Fprintf(env.Stderr, "deleted: %s\n", path)
+	}
+	if params.Loader != nil {
+		var paths []string
+		for _, f := range written {
+			paths = append(paths, env.abs(f.Path()))
+		}
+		for _, path := range res.Stale {
+			paths = append(paths, env.abs(path))
+		}
+		params.Loader.written(log, paths)
+	}
+	return nil
+}
+
+func writeStdout(env *Environment, files []*gogen.File) error {
+	if len(files) != 1 {
+		return fmt.Errorf("expected single file to print to stdout, but got %v", len(files))
+	}
+	content, err := files[0].Content()
+	if err != nil {
+		return fmt.Errorf("render: %w", err)
+	}
+	_, err = env.Stdout.Write(content)
+	if err != nil {
+		return fmt.Errorf("stdout write: %w", err)
 	}
 	return nil
 }
 
-func generateAll(env *Environment, pkgs []*packages.Package, params *params) ([]*gogen.File, error) {
+// checkNotOverwritingHandWritten returns error, if some of files exists and is not generated.
+func checkNotOverwritingHandWritten(env *Environment, files []*gogen.File) error {
+	for _, f := range files {
+		content, err := afero.ReadFile(env.fs(), f.Path())
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("file %s: read: %w", f.Path(), err)
+		}
+		if !isGenerated(content) {
+			return fmt.Errorf("file %s exists and is not generated, so refusing to overwrite it.\n"+
+				"Check --dst, or pass --force to overwrite it anyway", f.Path())
+		}
+	}
+	return nil
+}
+
+// isGenerated returns true, if Go file content has 'Code generated ... DO NOT EDIT.' comment.
+// See https://golang.org/s/generatedcode.
+func isGenerated(content []byte) bool {
+	// Partially parsed file is returned on syntax errors, and that is enough for the check.
+	file, _ := parser.ParseFile(token.NewFileSet(), "", content, parser.PackageClauseOnly|parser.ParseComments)
+	if file == nil {
+		return false
+	}
+	return ast.IsGenerated(file)
+}
+
+// generated is result of generation, that is not written yet.
+type generated struct {
+	Files []*gogen.File
+	// Stale are paths of previously generated files, that should be deleted. Set only when pruning.
+	Stale []string
+	// Changed are interfaces, which method set is changed since previous generation.
+	Changed []string
+}
+
+func (g *generated) add(other *generated) {
+	g.Files = append(g.Files, other.Files...)
+	g.Stale = append(g.Stale, other.Stale...)
+	g.Changed = append(g.Changed, other.Changed...)
+}
+
+// generate loads source packages and renders files, but doesn't write them.
+// Files are taken from generation cache, when nothing they depend on has changed.
+func generate(ctx context.Context, env *Environment, params *params) (*generated, error) {
+	cache := newGenerationCache(env, params)
+	if cache != nil {
+		if files, ok := cache.get(); ok {
+			return &generated{Files: files}, nil
+		}
+	}
+	res, err := generateUncached(ctx, env, params)
+	if err != nil {
+		return nil, err
+	}
+	// Files are rendered concurrently here, as they are rendered one by one on write or check otherwise.
+	// Render error is remembered in file, and reported on its write or check, like without prior render.
+	_ = gogen.RenderFiles(res.Files, 0)
+	if cache != nil {
+		cache.put(res.Files)
+	}
+	return res, nil
+}
+
+func generateUncached(ctx context.Context, env *Environment, params *params) (*generated, error) {
 	log := params.Log
-	srcPrimaryPkg := pkgs[0]
-	dstDir := strings.TrimPrefix(params.Destination, ".")
-	fileNamePattern := placeHolder + ".go"
-	if path.Ext(dstDir) == ".go" {
-		dstDir, fileNamePattern = path.Split(dstDir)
-		if dstDir == "" {
-			dstDir = "."
+	load := func(tests bool) ([]*packages.Package, error) {
+		switch {
+		case params.Mode == sourceLoadMode:
+			return loadSourcePackages(ctx, log, env, tests, params.Source)
+		case params.Loader != nil:
+			return params.Loader.load(ctx, log, env, params.Source, tests)
+		default:
+			return loadPackages(ctx, log, env, tests, params.Source)
+		}
+	}
+	tests := needTestPackages(params)
+	pkgs, err := load(tests)
+	if err == nil && !tests {
+		if missing := missingInterfaceNames(pkgs, params.Selector.names); len(missing) != 0 {
+			log.Debugf("Interfaces %s are not found in primary package, so test packages are loaded too", strings.Join(missing, ", "))
+			pkgs, err = load(true)
+		}
+	}
+	if err != nil {
+		errStr := err.Error()
+		if strings.Contains(errStr, "\n") {
+			errStr = "\n" + errStr
+		}
+		return nil, fmt.Errorf("package '%s' load failed: %s", params.Source, errStr)
+	}
+	if isRecursivePattern(params.Source) {
+		return generateRecursive(ctx, env, pkgs, params)
+	}
+	log.Infof("Processing package: %s", pkgs[0].ID)
+	loaded := pkgs
+	if params.Loader != nil {
+		if all := params.Loader.loaded(); len(all) != 0 {
+			// Destination package may be already loaded too.
+			loaded = all
+		}
+	}
+	return generateAll(ctx, env, loaded, pkgs, "", params)
+}
+
+// generateRecursive generates files for every package matched by recursive source pattern.
+// Destination is resolved relative to each package directory.
+func generateRecursive(ctx context.Context, env *Environment, loaded []*packages.Package, params *params) (*generated, error) {
+	log := params.Log
+	groups := groupPackagesByDir(log, loaded)
+	// Destination packages are matched by pattern too, but code should not be generated for generated code.
+	dstDirSources := map[string]string{}
+	for _, group := range groups {
+		dstDir, _ := splitDestination(params.Destination, group.pkgs[0].Name)
+		dstDirSources[filepath.Join(group.dir, dstDir)] = group.dir
+	}
+	res := &generated{}
+	for _, group := range groups {
+		if src, ok := dstDirSources[group.dir]; ok && src != group.dir {
+			log.Debugf("Skipping package %s, as it is destination of package in %s", group.pkgs[0].ID, src)
+			continue
+		}
+		baseDir, err := filepath.Rel(env.Dir, group.dir)
+		if err != nil {
+			return nil, fmt.Errorf("package %s dir: %w", group.pkgs[0].PkgPath, err)
+		}
+		log.Infof("Processing package: %s", group.pkgs[0].ID)
+		groupRes, err := generateAll(ctx, env, loaded, group.pkgs, baseDir, params)
+		if err != nil {
+			return nil, fmt.Errorf("package %s: %w", group.pkgs[0].PkgPath, err)
 		}
+		res.add(groupRes)
 	}
-	dstDir = strings.ReplaceAll(dstDir, placeHolder, srcPrimaryPkg.Name)
+	return res, nil
+}
 
-	packageName, err := getPackageName(log, params.Package, dstDir, srcPrimaryPkg, env)
+// generateAll generates files for package loaded as pkgs, that are primary package and its test packages.
+// Destination is resolved relative to baseDir, that is relative to Environment.Dir.
+// All loaded packages are passed to avoid extra loads.
+func generateAll(ctx context.Context, env *Environment, loaded []*packages.Package, pkgs []*packages.Package, baseDir string, params *params) (*generated, error) {
+	log := params.Log
+	ifaces, err := selectInterfaces(log, env.fs(), pkgs, params.Selector)
 	if err != nil {
-		return nil, fmt.Errorf("get generated file package name: %w", err)
+		return nil, err
+	}
+	// Annotated interfaces syntax is loaded on select.
+	if !params.Selector.annotated {
+		loadInterfacesSyntax(log, env.fs(), pkgs, ifaces)
+		// Mock name is taken from annotation for any selection, so file regenerated by recorded command keeps it.
+		err = setAnnotatedMockNames(ifaces)
+		if err != nil {
+			return nil, err
+		}
 	}
-	importPath := path.Join(srcPrimaryPkg.PkgPath, dstDir)
 
 	g := gmg.NewGMG(log)
-
-	ifaces, err := selectInterfaces(log, pkgs, params.Selector)
+	res := &generated{}
+	groups, err := groupByDestination(env, params, pkgs, baseDir, ifaces)
 	if err != nil {
 		return nil, err
 	}
+	for _, group := range groups {
+		err := generateDestination(ctx, env, g, loaded, pkgs, params, group, res)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if params.Prune {
+		dstDir, _ := splitDestination(params.Destination, pkgs[0].Name)
+		res.Stale, err = staleFiles(log, env, filepath.Join(baseDir, dstDir), res.Files, pkgs, ifaces, params.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("prune: %w", err)
+		}
+	}
+	return res, nil
+}
 
-	isSingleFile := !strings.Contains(fileNamePattern, placeHolder)
-	if isSingleFile {
-		g.GenerateFile(gmg.GenerateFileParams{
-			FilePath:    fileNamePattern,
+// generateDestination generates files of interfaces group to its destination, and adds them to res.
+func generateDestination(ctx context.Context, env *Environment, g *gmg.GMG, loaded []*packages.Package, pkgs []*packages.Package, params *params, group destinationGroup, res *generated) error {
+	log := params.Log
+	baseDir := group.baseDir
+	srcPrimaryPkg := pkgs[0]
+	dstDir, fileNamePattern := splitDestination(group.destination, srcPrimaryPkg.Name)
+	if group.destination == stdoutDestination {
+		// Printed file has no location, so it is treated as located in source package dir, like '--dst ./file.go'.
+		dstDir, fileNamePattern = ".", stdoutDestination
+	}
+
+	packageName, err := getPackageName(ctx, log, params.Package, filepath.Join(baseDir, dstDir), srcPrimaryPkg, loaded, env)
+	if err != nil {
+		return fmt.Errorf("get generated file package name: %w", err)
+	}
+	importPath, ok := dirImportPath(env, filepath.Join(baseDir, dstDir))
+	if !ok {
+		log.Debugf("Destination dir is not in module, so its import path is deduced from source package")
+		importPath = path.Join(srcPrimaryPkg.PkgPath, dstDir)
+	}
+	if strings.HasSuffix(packageName, "_test") {
+		// Black-box test package import path has the same suffix as its name.
+		importPath += "_test"
+	}
+
+	opts := gmg.GenerateOptions{
+		Kind:          params.Kind,
+		Template:      params.Template,
+		SharedRuntime: params.SharedRuntime,
+		GoVersion:     goVersion(env, filepath.Join(baseDir, dstDir), srcPrimaryPkg),
+	}
+
+	srcImportPath := srcPrimaryPkg.PkgPath
+	if primary := getPackageByKind(pkgs, primaryPackageKind); primary != nil {
+		srcImportPath = primary.PkgPath
+	}
+	generateFile := func(filePath string, ifaces []gmg.Interface) error {
+		cmd, err := reproduceCommand(env, params, srcImportPath, packageName, filePath, ifaces)
+		if err != nil {
+			return err
+		}
+		prev, changed := previouslyGenerated(env, params, filePath, cmd, opts.RequiredGoVersion(), ifaces)
+		res.Changed = append(res.Changed, changed...)
+		if prev != nil {
+			res.Files = append(res.Files, prev)
+			return nil
+		}
+		err = g.GenerateFile(gmg.GenerateFileParams{
+			FilePath:    filePath,
 			ImportPath:  importPath,
 			PackageName: packageName,
 			Interfaces:  ifaces,
+			Options:     opts,
+			Command:     cmd,
 		})
-	} else {
-		for _, iface := range ifaces {
-			baseName := strings.ReplaceAll(fileNamePattern, placeHolder, strcase.ToSnake(iface.Name))
-			filePath := filepath.Join(dstDir, baseName)
-			g.GenerateFile(gmg.GenerateFileParams{
-				FilePath:    filePath,
-				ImportPath:  importPath,
-				PackageName: packageName,
-				Interfaces:  []gmg.Interface{iface},
-			})
+		if err != nil {
+			return fmt.Errorf("file %s: %w", filePath, err)
+		}
+		files := g.Files()
+		res.Files = append(res.Files, files[len(files)-1])
+		return nil
+	}
+
+	isSingleFile := !strings.Contains(fileNamePattern, placeHolder)
+	if isSingleFile {
+		return generateFile(filepath.Join(baseDir, dstDir, fileNamePattern), group.ifaces)
+	}
+	for _, iface := range group.ifaces {
+		baseName := strings.ReplaceAll(fileNamePattern, placeHolder, strcase.ToSnake(iface.Name))
+		err := generateFile(filepath.Join(baseDir, dstDir, baseName), []gmg.Interface{iface})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reproduceCommand returns gmg args, that generate the same file, when run in its dir.
+// Source is passed as import path and destination as file name, so command doesn't depend on the original working dir.
+func reproduceCommand(env *Environment, params *params, srcImportPath string, packageName string, filePath string, ifaces []gmg.Interface) ([]string, error) {
+	dst := "./" + filepath.Base(filePath)
+	if filePath == stdoutDestination {
+		dst = stdoutDestination
+	}
+	args := []string{"--src", srcImportPath, "--dst", dst, "--pkg", packageName}
+	if params.Template != nil {
+		tmpl, err := filepath.Rel(env.abs(filepath.Dir(filePath)), params.TemplatePath)
+		if err != nil {
+			return nil, fmt.Errorf("template path relative to %s: %w", filePath, err)
+		}
+		if !strings.HasPrefix(tmpl, "..") {
+			tmpl = "./" + tmpl
 		}
+		args = append(args, "--template", filepath.ToSlash(tmpl))
+	} else if params.Kind != gmg.KindMock {
+		args = append(args, "--kind", params.Kind.String())
 	}
-	return g.Files(), nil
+	if params.Mode != packagesLoadMode {
+		args = append(args, "--mode", string(params.Mode))
+	}
+	if params.SharedRuntime {
+		args = append(args, "--shared-runtime")
+	}
+	for _, iface := range ifaces {
+		args = append(args, iface.Name)
+	}
+	return args, nil
+}
+
+// splitDestination returns destination dir and file name pattern, with '{}' in dir replaced by package name.
+func splitDestination(destination string, packageName string) (string, string) {
+	dstDir := strings.TrimPrefix(destination, ".")
+	fileNamePattern := placeHolder + ".go"
+	if path.Ext(dstDir) == ".go" {
+		dstDir, fileNamePattern = path.Split(dstDir)
+		if dstDir == "" {
+			dstDir = "."
+		}
+	}
+	return strings.ReplaceAll(dstDir, placeHolder, packageName), fileNamePattern
 }
 
-func getPackageName(log *zap.SugaredLogger, packageNameTemplate string, dstDir string, srcPrimaryPkg *packages.Package, env *Environment) (string, error) {
-	const defaultPackageNameTemplate = "mocks_{}"
+func getPackageName(ctx context.Context, log *zap.SugaredLogger, packageNameTemplate string, dstDir string, srcPrimaryPkg *packages.Package, loaded []*packages.Package, env *Environment) (string, error) {
 	if packageNameTemplate != "" {
 		log.Debugf("Package name template explisitly set - using it")
 		return executePackageNameTemplate(packageNameTemplate, srcPrimaryPkg), nil
 	}
-	if _, err := os.Stat(dstDir); os.IsNotExist(err) {
+	dstDirExists, err := afero.DirExists(env.fs(), dstDir)
+	if err != nil {
+		return "", fmt.Errorf("destination dir '%s' stat: %w", dstDir, err)
+	}
+	if !dstDirExists {
 		log.Debugf("Package name is not set, but destination dir '%s' is not exist - using default", dstDir)
 		return executePackageNameTemplate(defaultPackageNameTemplate, srcPrimaryPkg), nil
 	}
-
-	absDstDir, err := filepath.Abs(dstDir)
+	absDstDir := env.abs(dstDir)
+	for _, pkg := range loaded {
+		if getPackageKind(pkg) == primaryPackageKind && packageDir(pkg) == absDstDir {
+			log.Debugf("Destination dir package is already loaded - going to use its package name '%s'", pkg.Name)
+			return pkg.Name, nil
+		}
+	}
+	overlay, err := env.overlay(absDstDir)
 	if err != nil {
-		return "", fmt.Errorf("destination dir '%s' abs: %w", dstDir, err)
+		return "", fmt.Errorf("destination dir '%s' read: %w", dstDir, err)
 	}
 
-	// TODO(skipor): optimise - check, maybe it already loaded in pkgs
-
 	log.Debugf("Package name is not set, and destination dir exists - trying to load go package, to get its name, to use it in generated files")
 	dstDirPkgs, err := packages.Load(&packages.Config{
+		Context:    ctx,
 		Mode:       packages.NeedName,
 		Dir:        env.Dir,
 		Env:        env.Env,
+		Overlay:    overlay,
 		BuildFlags: nil, // TODO(skipor)
 	}, absDstDir)
 
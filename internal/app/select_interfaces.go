@@ -6,10 +6,11 @@ import (
 	"go/parser"
 	"go/token"
 	"go/types"
-	"io/ioutil"
 	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/spf13/afero"
 	"go.uber.org/zap"
 	"golang.org/x/tools/go/packages"
 
@@ -17,30 +18,72 @@ import (
 )
 
 type interfaceSelector struct {
-	names    []string
-	all      bool
-	allFile  bool
-	goGenEnv goGenerateEnv
+	names   []string
+	all     bool
+	allFile bool
+	// annotated selects interfaces annotated with mockDirective.
+	annotated bool
+	// packageKind is kind of package, which interfaces are selected by all or annotated.
+	packageKind packageKind
+	goGenEnv    goGenerateEnv
+	// match and exclude filter interfaces selected by all, allFile or annotated. Nil, when not set.
+	match   *regexp.Regexp
+	exclude *regexp.Regexp
 }
 
-func selectInterfaces(log *zap.SugaredLogger, pkgs []*packages.Package, sel interfaceSelector) ([]gmg.Interface, error) {
+func selectInterfaces(log *zap.SugaredLogger, fs afero.Fs, pkgs []*packages.Package, sel interfaceSelector) ([]gmg.Interface, error) {
 	log.Debugf("Selecting interfaces: %+v", sel)
 	if len(sel.names) != 0 {
 		return selectInterfacesByNames(log, pkgs, sel.names)
 	}
-	if sel.all {
-		return selectAllPrimaryPackageInterfaces(log, pkgs)
+	if sel.all || sel.annotated {
+		var ifaces []gmg.Interface
+		var err error
+		if sel.all {
+			ifaces, err = selectAllPackagesInterfaces(log, pkgs, sel.packageKind)
+		} else {
+			ifaces, err = selectAnnotatedInterfaces(log, fs, pkgs, sel.packageKind)
+		}
+		if err != nil {
+			return nil, err
+		}
+		ifaces = sel.filter(log, ifaces)
+		return ifaces, checkDuplicateNames(ifaces)
 	}
 	if sel.allFile {
 		if !sel.goGenEnv.isSet() {
 			log.Panic("Validation failed: 'all-file' selector passed but no 'go generate' env set")
 		}
-		return selectAllFileInterfaces(log, pkgs, sel.goGenEnv)
+		ifaces, err := selectAllFileInterfaces(log, fs, pkgs, sel.goGenEnv)
+		return sel.filter(log, ifaces), err
 	}
 	if !sel.goGenEnv.isSet() {
 		log.Panic("Validation failed: neither selector passed nor 'go generate' env set")
 	}
-	return selectInterfaceCorrespondingToGoGenerateComment(log, pkgs, sel.goGenEnv)
+	return selectInterfaceCorrespondingToGoGenerateComment(log, fs, pkgs, sel.goGenEnv)
+}
+
+// filter returns interfaces, which names match --match and don't match --exclude regexps.
+func (sel interfaceSelector) filter(log *zap.SugaredLogger, ifaces []gmg.Interface) []gmg.Interface {
+	if sel.match == nil && sel.exclude == nil {
+		return ifaces
+	}
+	var filtered []gmg.Interface
+	for _, iface := range ifaces {
+		if sel.match != nil && !sel.match.MatchString(iface.Name) {
+			log.Debugf("Interface %s is not matched by --match '%s'", iface.Name, sel.match)
+			continue
+		}
+		if sel.exclude != nil && sel.exclude.MatchString(iface.Name) {
+			log.Debugf("Interface %s is matched by --exclude '%s'", iface.Name, sel.exclude)
+			continue
+		}
+		filtered = append(filtered, iface)
+	}
+	if len(filtered) == 0 && len(ifaces) != 0 {
+		log.Infof("All %v interfaces are filtered out by --match and --exclude", len(ifaces))
+	}
+	return filtered
 }
 
 func selectInterfacesByNames(log *zap.SugaredLogger, pkgs []*packages.Package, interfaceNames []string) ([]gmg.Interface, error) {
@@ -80,8 +123,8 @@ func selectInterfacesByNames(log *zap.SugaredLogger, pkgs []*packages.Package, i
 	return ifaces, nil
 }
 
-func selectInterfaceCorrespondingToGoGenerateComment(log *zap.SugaredLogger, pkgs []*packages.Package, goGenEnv goGenerateEnv) ([]gmg.Interface, error) {
-	pkg, file, fset, parseErr, err := parseGoGenerateCommentFile(log, pkgs, goGenEnv)
+func selectInterfaceCorrespondingToGoGenerateComment(log *zap.SugaredLogger, fs afero.Fs, pkgs []*packages.Package, goGenEnv goGenerateEnv) ([]gmg.Interface, error) {
+	pkg, file, fset, parseErr, err := parseGoGenerateCommentFile(log, fs, pkgs, goGenEnv)
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +159,7 @@ func selectInterfaceCorrespondingToGoGenerateComment(log *zap.SugaredLogger, pkg
 	}}, nil
 }
 
-func parseGoGenerateCommentFile(log *zap.SugaredLogger, pkgs []*packages.Package, goGenEnv goGenerateEnv) (*packages.Package, *ast.File, *token.FileSet, error, error) {
+func parseGoGenerateCommentFile(log *zap.SugaredLogger, fs afero.Fs, pkgs []*packages.Package, goGenEnv goGenerateEnv) (*packages.Package, *ast.File, *token.FileSet, error, error) {
 	pkg := getPackageByKind(pkgs, goGenEnv.packageKind())
 	if pkg == nil {
 		return nil, nil, nil, nil, fmt.Errorf(
@@ -130,7 +173,7 @@ func parseGoGenerateCommentFile(log *zap.SugaredLogger, pkgs []*packages.Package
 	// and that cause packages.Package.TypesInfo computation for all package files.
 	// So, seems that it is cheaper to parse only GOFILE by ourselves.
 
-	file, fset, parseErr := parseGOFILE(pkg, goGenEnv.GOFILE)
+	file, fset, parseErr := parseGOFILE(fs, pkg, goGenEnv.GOFILE)
 	if parseErr != nil {
 		if file == nil {
 			return nil, nil, nil, nil, fmt.Errorf("GOFILE '%s' parse: %w", goGenEnv.GOFILE, parseErr)
@@ -186,22 +229,63 @@ func correspondingTypeSpec(file *ast.File, fset *token.FileSet, goline int, pars
 	}
 }
 
-func selectAllPrimaryPackageInterfaces(log *zap.SugaredLogger, pkgs []*packages.Package) ([]gmg.Interface, error) {
-	// TODO(skipor): support both test packages from flags (--primary-pkg --test-pkg, --black-box-test-pkg ?)
-	//   or --all-test for both test packages?
-	pkg := getPackageByKind(pkgs, primaryPackageKind)
-	if pkg == nil {
-		log.Infof("There is no non *_test.go files, so --all selector doesn't select anythig")
-		return nil, nil
+// selectAllPackagesInterfaces returns all interfaces of package of kind, or of all primary, test and black-box test packages,
+// when kind is anyPackageKind. Test package contains primary package declarations too, so only ones from *_test.go files are selected from it.
+func selectAllPackagesInterfaces(log *zap.SugaredLogger, pkgs []*packages.Package, kind packageKind) ([]gmg.Interface, error) {
+	kinds := []packageKind{kind}
+	if kind == anyPackageKind {
+		kinds = []packageKind{primaryPackageKind, testPackageKind, blackBoxTestPackageKind}
+	}
+	var ifaces []gmg.Interface
+	for _, k := range kinds {
+		pkg := getPackageByKind(pkgs, k)
+		if pkg == nil {
+			log.Infof("There is no %s package files, so nothing is selected from it", k)
+			continue
+		}
+		pkgIfaces := selectAllPkgInterfaces(log, pkg)
+		if k == testPackageKind {
+			pkgIfaces = testFilesInterfaces(pkg, pkgIfaces)
+		}
+		ifaces = append(ifaces, pkgIfaces...)
 	}
-	ifaces := selectAllPkgInterfaces(log, pkg)
 	if len(ifaces) == 0 {
-		log.Infof("No interfaces found in non *_test.go files")
+		log.Infof("No interfaces found in %s package", kind)
 		return nil, nil
 	}
 	return ifaces, nil
 }
 
+// checkDuplicateNames returns error, when interfaces of primary or test package and black-box test package have the same name.
+// Their generated files and mock types would conflict, so such interfaces should be selected with specific --package-kind.
+func checkDuplicateNames(ifaces []gmg.Interface) error {
+	importPaths := map[string]string{}
+	for _, iface := range ifaces {
+		importPath, ok := importPaths[iface.Name]
+		if !ok {
+			importPaths[iface.Name] = iface.ImportPath
+			continue
+		}
+		if importPath != iface.ImportPath {
+			return fmt.Errorf("interface %s is declared in both %s and %s packages, so their generated code conflicts. "+
+				"Select interfaces of one package with specific --package-kind, or exclude one of them with --exclude", iface.Name, importPath, iface.ImportPath)
+		}
+	}
+	return nil
+}
+
+// testFilesInterfaces returns interfaces, that are declared in *_test.go files of pkg.
+func testFilesInterfaces(pkg *packages.Package, ifaces []gmg.Interface) []gmg.Interface {
+	var filtered []gmg.Interface
+	for _, iface := range ifaces {
+		obj := pkg.Types.Scope().Lookup(iface.Name)
+		if strings.HasSuffix(pkg.Fset.Position(obj.Pos()).Filename, "_test.go") {
+			filtered = append(filtered, iface)
+		}
+	}
+	return filtered
+}
+
 func selectAllPkgInterfaces(log *zap.SugaredLogger, pkg *packages.Package) []gmg.Interface {
 	// TODO(skipor): handle unexported methods and interfaces
 	log.Debugf("Selecting all interfaces from package %s of kind '%s'", pkg.ID, getPackageKind(pkg))
@@ -224,8 +308,8 @@ func selectAllPkgInterfaces(log *zap.SugaredLogger, pkg *packages.Package) []gmg
 	return ifaces
 }
 
-func selectAllFileInterfaces(log *zap.SugaredLogger, pkgs []*packages.Package, goGenEnv goGenerateEnv) ([]gmg.Interface, error) {
-	pkg, file, fset, _, err := parseGoGenerateCommentFile(log, pkgs, goGenEnv)
+func selectAllFileInterfaces(log *zap.SugaredLogger, fs afero.Fs, pkgs []*packages.Package, goGenEnv goGenerateEnv) ([]gmg.Interface, error) {
+	pkg, file, fset, _, err := parseGoGenerateCommentFile(log, fs, pkgs, goGenEnv)
 	if err != nil {
 		return nil, err
 	}
@@ -285,7 +369,7 @@ func lineDeclSpec(fset *token.FileSet, decl *ast.GenDecl, goline int) ast.Spec {
 	return nil
 }
 
-func parseGOFILE(pkg *packages.Package, gofile string) (*ast.File, *token.FileSet, error) {
+func parseGOFILE(fs afero.Fs, pkg *packages.Package, gofile string) (*ast.File, *token.FileSet, error) {
 	filePath := gofilePath(pkg, gofile)
 	if filePath == "" {
 		return nil, nil, fmt.Errorf(
@@ -295,7 +379,7 @@ func parseGOFILE(pkg *packages.Package, gofile string) (*ast.File, *token.FileSe
 		)
 	}
 
-	fileData, err := ioutil.ReadFile(filePath)
+	fileData, err := afero.ReadFile(fs, filePath)
 	if err != nil {
 		return nil, nil, fmt.Errorf("GOFILE '%s' read: %w", filePath, err)
 	}
@@ -310,7 +394,7 @@ func parseGOFILE(pkg *packages.Package, gofile string) (*ast.File, *token.FileSe
 }
 
 func gofilePath(pkg *packages.Package, gofile string) string {
-	for _, absPath := range pkg.CompiledGoFiles {
+	for _, absPath := range pkg.GoFiles {
 		baseName := filepath.Base(absPath)
 		if baseName == gofile {
 			return absPath
@@ -0,0 +1,285 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	serveSubcommand = "serve"
+	// serverSocketEnv is server socket path, that gmg forwards runs to. Forwarding is disabled, when it is not set.
+	serverSocketEnv = "GMG_SOCKET"
+	// serverDialTimeout is small, as it is spent on every run, when server is not running.
+	serverDialTimeout = 100 * time.Millisecond
+)
+
+// Serve runs gmg server like 'gmg serve', parsing env.Args as its flags, until ctx is done.
+func Serve(ctx context.Context, env *Environment) error {
+	return serve(ctx, env, env.Args)
+}
+
+// serve runs gmg server, that runs gmg forwarded from other processes, keeping loaded packages cached between runs.
+func serve(ctx context.Context, env *Environment, args []string) error {
+	fs := pflag.NewFlagSet("gmg serve", pflag.ContinueOnError)
+	fs.Usage = func() {
+		b := &bytes.Buffer{}
+		p := func(format string, a ...interface{}) { _, _ = fmt.Fprintf(b, format, a...) }
+		p("Usage: gmg serve [--socket <path>]\n\n")
+		p("Runs gmg server, listening on Unix socket, until interrupted.\n")
+		p("When %s environment variable is set to server socket path, gmg forwards args, working dir and environment to server,\n", serverSocketEnv)
+		p("and prints its output. Without it, gmg never forwards runs.\n")
+		p("Server keeps loaded packages type information cached, until their files are changed, so regeneration is much faster.\n")
+		p("Server runs only requests of gmg built from the same executable, so restart it after gmg update.\n\n")
+		p("Flags:\n%s", fs.FlagUsages())
+		_, _ = b.WriteTo(env.Stderr)
+	}
+	var (
+		socket string
+		debug  bool
+	)
+	defaultSocket := env.ServerSocket
+	if defaultSocket == "" {
+		defaultSocket = defaultServerSocket()
+	}
+	fs.StringVar(&socket, "socket", defaultSocket, "Unix socket path to listen on. "+serverSocketEnv+" or per user path by default.")
+	fs.BoolVar(&debug, "debug", env.Getenv("GMG_DEBUG") != "", "Verbose debug logging.")
+	err := fs.Parse(args)
+	if err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return errExitZero
+		}
+		return fmt.Errorf("flags parse: %w", err)
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected args: %s", fs.Args())
+	}
+	if socket == "" {
+		return fmt.Errorf("--socket: server socket path is not set")
+	}
+	level := zapcore.InfoLevel
+	if debug {
+		level = zapcore.DebugLevel
+	}
+	log := newLogger(env, level)
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	l, err := listenSocket(env.abs(socket))
+	if err != nil {
+		return err
+	}
+	log.Infof("Listening on %s. Set %s=%s to forward gmg runs to server", socket, serverSocketEnv, env.abs(socket))
+	s := &server{log: log, cache: newPackageCache()}
+	return s.serve(ctx, l)
+}
+
+// listenSocket listens on Unix socket, removing socket file left by crashed server.
+// Socket is accessible only by current user.
+func listenSocket(socket string) (net.Listener, error) {
+	dir := filepath.Dir(socket)
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("socket dir: %w", err)
+	}
+	err = checkSocketDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("socket dir: %w", err)
+	}
+	if _, err := os.Lstat(socket); err == nil {
+		err = checkSocket(socket)
+		if err != nil {
+			return nil, fmt.Errorf("existing socket can't be reused: %w", err)
+		}
+		conn, err := net.DialTimeout("unix", socket, serverDialTimeout)
+		if err == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("server is already running on %s", socket)
+		}
+		err = os.Remove(socket)
+		if err != nil {
+			return nil, fmt.Errorf("stale socket remove: %w", err)
+		}
+	}
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, err
+	}
+	err = os.Chmod(socket, 0600)
+	if err != nil {
+		_ = l.Close()
+		return nil, fmt.Errorf("socket chmod: %w", err)
+	}
+	return l, nil
+}
+
+type server struct {
+	log   *zap.SugaredLogger
+	cache *packageCache
+}
+
+func (s *server) serve(ctx context.Context, l net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		_ = l.Close()
+	}()
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				s.log.Infof("Server stopped")
+				return nil
+			}
+			return fmt.Errorf("accept: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conn.Close()
+			s.handle(ctx, conn)
+		}()
+	}
+}
+
+// serverRequest is Environment forwarded to server.
+type serverRequest struct {
+	// Version is client gmg version. Server refuses to run other version, as it may generate different code.
+	Version string
+	// Executable is client executableIdentity. Server refuses to run for other executable,
+	// as generated code, cache keys and incremental skips depend on it.
+	Executable string
+	Args       []string
+	Dir        string
+	Env        []string
+	CacheDir   string
+}
+
+type serverResponse struct {
+	// Error is set, when server refused to run, so run should be done by client itself.
+	Error    string
+	ExitCode int
+	Stdout   []byte
+	Stderr   []byte
+}
+
+func (s *server) handle(ctx context.Context, conn net.Conn) {
+	var req serverRequest
+	err := json.NewDecoder(conn).Decode(&req)
+	if err != nil {
+		s.log.Warnf("Request decode failed: %s", err)
+		return
+	}
+	var resp serverResponse
+	if req.Version != gmgVersion {
+		resp.Error = fmt.Sprintf("server version %s differs from client version %s", gmgVersion, req.Version)
+	} else if exe := executableIdentity(); req.Executable != exe {
+		resp.Error = fmt.Sprintf("server executable '%s' differs from client one '%s'. Restart server", exe, req.Executable)
+	} else {
+		s.log.Debugf("Run in %s: %q", req.Dir, req.Args)
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		resp.ExitCode = runMain(ctx, &Environment{
+			Args:     req.Args,
+			Stdout:   stdout,
+			Stderr:   stderr,
+			Dir:      req.Dir,
+			Env:      req.Env,
+			Fs:       afero.NewOsFs(),
+			CacheDir: req.CacheDir,
+		}, s.cache)
+		resp.Stdout, resp.Stderr = stdout.Bytes(), stderr.Bytes()
+	}
+	err = json.NewEncoder(conn).Encode(resp)
+	if err != nil {
+		s.log.Warnf("Response encode failed: %s", err)
+	}
+}
+
+// forward runs gmg on server, if it is listening on Environment.ServerSocket.
+// False is returned, when run should be done in this process.
+func forward(env *Environment) (int, bool) {
+	if env.ServerSocket == "" || (len(env.Args) != 0 && env.Args[0] == serveSubcommand) {
+		return 0, false
+	}
+	if _, ok := env.Fs.(*afero.OsFs); !ok || env.LogCore != nil {
+		// Server can't see files that are not on disk, and can't log to passed core.
+		return 0, false
+	}
+	socket := env.abs(env.ServerSocket)
+	if _, err := os.Lstat(socket); err != nil {
+		// Server is not running.
+		return 0, false
+	}
+	err := checkSocket(socket)
+	if err != nil {
+		_, _ = fmt.Fprintf(env.Stderr, "WARN: gmg server socket is not trusted: %s. Running without server.\n", err)
+		return 0, false
+	}
+	conn, err := net.DialTimeout("unix", socket, serverDialTimeout)
+	if err != nil {
+		// Server is not running.
+		return 0, false
+	}
+	defer conn.Close()
+	err = json.NewEncoder(conn).Encode(serverRequest{
+		Version:    gmgVersion,
+		Executable: executableIdentity(),
+		Args:       env.Args,
+		Dir:        env.Dir,
+		Env:        env.Env,
+		CacheDir:   env.CacheDir,
+	})
+	var resp serverResponse
+	if err == nil {
+		err = json.NewDecoder(conn).Decode(&resp)
+	}
+	if err == nil && resp.Error != "" {
+		err = errors.New(resp.Error)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(env.Stderr, "WARN: gmg server on %s: %s. Running without server.\n", env.ServerSocket, err)
+		return 0, false
+	}
+	_, _ = env.Stdout.Write(resp.Stdout)
+	_, _ = env.Stderr.Write(resp.Stderr)
+	return resp.ExitCode, true
+}
+
+// executableIdentity returns path, size and modification time of gmg executable, that change, when it is rebuilt.
+// Empty string is returned, when executable is unknown.
+func executableIdentity() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	info, err := os.Stat(exe)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s %v %v", exe, info.Size(), info.ModTime().UnixNano())
+}
+
+// defaultServerSocket returns server socket path, that is per user, so servers of different users don't interfere.
+// Socket is put in $XDG_RUNTIME_DIR, or in per user temp dir, that is created by server with 0700 mode.
+func defaultServerSocket() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "gmg.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("gmg-%d", os.Getuid()), "gmg.sock")
+}
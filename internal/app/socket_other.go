@@ -0,0 +1,18 @@
+//go:build !unix
+
+package app
+
+import "os"
+
+// checkSocket returns error, if socket file does not exist.
+// File ownership is not checked, as it is Unix specific.
+func checkSocket(socket string) error {
+	_, err := os.Lstat(socket)
+	return err
+}
+
+// checkSocketDir returns error, if dir does not exist.
+func checkSocketDir(dir string) error {
+	_, err := os.Lstat(dir)
+	return err
+}
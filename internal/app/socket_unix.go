@@ -0,0 +1,60 @@
+//go:build unix
+
+package app
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// checkSocket returns error, if socket file is not a socket owned by current user, or other users can write to it.
+// Such socket may be created by other user, to intercept gmg runs.
+func checkSocket(socket string) error {
+	info, err := os.Lstat(socket)
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s is not a socket", socket)
+	}
+	if err := checkOwner(socket, info); err != nil {
+		return err
+	}
+	if info.Mode().Perm()&0022 != 0 {
+		return fmt.Errorf("socket %s is writable by other users", socket)
+	}
+	return nil
+}
+
+// checkSocketDir returns error, if other users can replace socket in dir.
+// Dir should be owned by current user or root, and not writable by other users, unless it is sticky like /tmp.
+func checkSocketDir(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); !ok || stat.Uid != 0 {
+		if err := checkOwner(dir, info); err != nil {
+			return err
+		}
+	}
+	if info.Mode().Perm()&0022 != 0 && info.Mode()&os.ModeSticky == 0 {
+		return fmt.Errorf("socket dir %s is writable by other users", dir)
+	}
+	return nil
+}
+
+func checkOwner(path string, info os.FileInfo) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fmt.Errorf("%s owner is unknown", path)
+	}
+	if int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("%s is owned by other user (uid %d)", path, stat.Uid)
+	}
+	return nil
+}
@@ -0,0 +1,631 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"go.uber.org/zap"
+	"golang.org/x/tools/go/packages"
+)
+
+// loadMode is the way source package type information is loaded.
+type loadMode string
+
+const (
+	// packagesLoadMode loads source package and its whole dependency graph via golang.org/x/tools/go/packages.
+	packagesLoadMode loadMode = "packages"
+	// sourceLoadMode parses source package files and type checks them, like mockgen -source does.
+	// Packages of the same module are type checked from source lazily, and other packages are imported from export data.
+	// Type expressions that can't be resolved, because of dependency errors, are taken from syntax as is.
+	sourceLoadMode loadMode = "source"
+)
+
+func isKnownLoadMode(mode string) bool {
+	return mode == string(packagesLoadMode) || mode == string(sourceLoadMode)
+}
+
+// loadSourcePackages loads src packages in source mode.
+// Returned packages are like ones loaded by loadPackages, but have only name, files, types and module info.
+// Test packages are type checked only when tests is set.
+func loadSourcePackages(ctx context.Context, log *zap.SugaredLogger, env *Environment, tests bool, src string) ([]*packages.Package, error) {
+	log.Debugf("Loading package from source (tests: %v): %s", tests, src)
+	dir, importPath, err := findSourceDir(ctx, env, src)
+	if err != nil {
+		return nil, err
+	}
+	ctxt := sourceBuildContext(env)
+	bp, err := ctxt.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	l := newSourceModeLoader(ctx, log, env, ctxt, dir)
+	var pkgs []*packages.Package
+	newPackage := func(id string, pkgPath string, files []string) (*packages.Package, error) {
+		checked, err := l.check(pkgPath, dir, files)
+		if err != nil {
+			return nil, err
+		}
+		paths := make([]string, len(files))
+		for i, file := range files {
+			paths[i] = filepath.Join(dir, file)
+		}
+		pkg := &packages.Package{
+			ID:              id,
+			Name:            checked.types.Name(),
+			PkgPath:         pkgPath,
+			Errors:          checked.errs,
+			GoFiles:         paths,
+			CompiledGoFiles: paths,
+			Types:           checked.types,
+			Fset:            l.fset,
+		}
+		for _, file := range bp.IgnoredGoFiles {
+			pkg.IgnoredFiles = append(pkg.IgnoredFiles, filepath.Join(dir, file))
+		}
+		if m, ok := findModule(env, dir); ok {
+			pkg.Module = &packages.Module{Path: m.Path, Dir: m.Dir, GoMod: filepath.Join(m.Dir, "go.mod"), GoVersion: m.GoVersion}
+		}
+		pkgs = append(pkgs, pkg)
+		return pkg, nil
+	}
+	primaryFiles := append(append([]string{}, bp.GoFiles...), bp.CgoFiles...)
+	_, err = newPackage(importPath, importPath, primaryFiles)
+	if err != nil {
+		return nil, err
+	}
+	// IDs are the same as packages.Load returns for test packages, as package kind is deduced from them.
+	testID := importPath + " [" + importPath + ".test]"
+	if tests && len(bp.TestGoFiles) != 0 {
+		testPkg, err := newPackage(testID, importPath, append(primaryFiles, bp.TestGoFiles...))
+		if err != nil {
+			return nil, err
+		}
+		// Black-box test package imports primary package with test files.
+		l.pkgs[importPath] = testPkg.Types
+	}
+	if tests && len(bp.XTestGoFiles) != 0 {
+		_, err := newPackage(importPath+"_test ["+importPath+".test]", importPath+"_test", bp.XTestGoFiles)
+		if err != nil {
+			return nil, err
+		}
+	}
+	debugLogPkgs(log, pkgs)
+	warnPackagesErrors(log, pkgs)
+	return pkgs, nil
+}
+
+// findSourceDir returns dir and import path of src package.
+// Packages of main module are found without running go command.
+func findSourceDir(ctx context.Context, env *Environment, src string) (string, string, error) {
+	if isLocalSource(src) {
+		dir := env.abs(src)
+		if importPath, ok := dirImportPath(env, dir); ok {
+			return dir, importPath, nil
+		}
+	} else if m, ok := findModule(env, "."); ok {
+		if dir, ok := m.importPathDir(src); ok {
+			return dir, src, nil
+		}
+	}
+	cmd := exec.CommandContext(ctx, "go", "list", "-find", "-f", "{{.Dir}}\t{{.ImportPath}}", "--", src)
+	cmd.Dir = env.Dir
+	cmd.Env = env.Env
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("go list: %w\n%s", err, strings.TrimSpace(stderr.String()))
+	}
+	dir, importPath, ok := strings.Cut(strings.TrimSpace(string(out)), "\t")
+	if !ok || dir == "" {
+		return "", "", fmt.Errorf("package %s dir is not found", src)
+	}
+	return dir, importPath, nil
+}
+
+// sourceBuildContext returns build context, that selects files like go command does in the environment.
+func sourceBuildContext(env *Environment) *build.Context {
+	ctxt := build.Default
+	if goos := env.Getenv("GOOS"); goos != "" {
+		ctxt.GOOS = goos
+	}
+	if goarch := env.Getenv("GOARCH"); goarch != "" {
+		ctxt.GOARCH = goarch
+	}
+	if cgo := env.Getenv("CGO_ENABLED"); cgo != "" {
+		ctxt.CgoEnabled = cgo == "1"
+	}
+	if tags, ok := goFlag(env.Getenv("GOFLAGS"), "tags"); ok {
+		ctxt.BuildTags = strings.FieldsFunc(tags, func(r rune) bool { return r == ',' || r == ' ' })
+	}
+	fs := env.fs()
+	ctxt.ReadDir = func(dir string) ([]os.FileInfo, error) { return afero.ReadDir(fs, dir) }
+	ctxt.OpenFile = func(path string) (io.ReadCloser, error) { return fs.Open(path) }
+	return &ctxt
+}
+
+// goFlag returns value of last flag with name in GOFLAGS, parsed like go command does:
+// fields are separated by whitespace, flag may have one or two dashes, and value may be set as -flag=value or -flag value.
+func goFlag(goflags, name string) (string, bool) {
+	var value string
+	var found bool
+	fields := strings.Fields(goflags)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if !strings.HasPrefix(field, "-") {
+			continue
+		}
+		field = strings.TrimPrefix(strings.TrimPrefix(field, "-"), "-")
+		flagName, flagValue, hasValue := strings.Cut(field, "=")
+		if flagName != name {
+			continue
+		}
+		if !hasValue && i+1 < len(fields) && !strings.HasPrefix(fields[i+1], "-") {
+			i++
+			flagValue = fields[i]
+		}
+		value, found = flagValue, true
+	}
+	return value, found
+}
+
+// sourceModeLoader type checks packages from source, importing their dependencies lazily.
+type sourceModeLoader struct {
+	ctx  context.Context
+	log  *zap.SugaredLogger
+	env  *Environment
+	ctxt *build.Context
+	// dir is dir of source package, that imports are resolved from.
+	dir    string
+	module module
+	// inModule is false, when source package is not in module. Then, all imports are loaded from export data.
+	inModule bool
+	fset     *token.FileSet
+	// pkgs are already imported packages by import path.
+	pkgs map[string]*types.Package
+	// exports are export data files by import path. Empty, when export data is not available.
+	exports map[string]string
+	gc      types.Importer
+}
+
+func newSourceModeLoader(ctx context.Context, log *zap.SugaredLogger, env *Environment, ctxt *build.Context, dir string) *sourceModeLoader {
+	l := &sourceModeLoader{
+		ctx:     ctx,
+		log:     log,
+		env:     env,
+		ctxt:    ctxt,
+		dir:     dir,
+		fset:    token.NewFileSet(),
+		pkgs:    map[string]*types.Package{},
+		exports: map[string]string{},
+	}
+	l.module, l.inModule = findModule(env, dir)
+	l.gc = importer.ForCompiler(l.fset, "gc", func(importPath string) (io.ReadCloser, error) {
+		export := l.exports[importPath]
+		if export == "" {
+			return nil, fmt.Errorf("no export data for %s", importPath)
+		}
+		return os.Open(export)
+	})
+	return l
+}
+
+type checkedPackage struct {
+	types *types.Package
+	errs  []packages.Error
+}
+
+// Import implements types.Importer.
+func (l *sourceModeLoader) Import(importPath string) (*types.Package, error) {
+	if importPath == "unsafe" {
+		return types.Unsafe, nil
+	}
+	if pkg, ok := l.pkgs[importPath]; ok {
+		if pkg == nil {
+			return nil, fmt.Errorf("import cycle via %s", importPath)
+		}
+		return pkg, nil
+	}
+	if dir, ok := l.localDir(importPath); ok {
+		l.pkgs[importPath] = nil
+		bp, err := l.ctxt.ImportDir(dir, 0)
+		if err != nil {
+			delete(l.pkgs, importPath)
+			return nil, err
+		}
+		checked, err := l.check(importPath, dir, append(bp.GoFiles, bp.CgoFiles...))
+		if err != nil {
+			delete(l.pkgs, importPath)
+			return nil, err
+		}
+		if len(checked.errs) != 0 {
+			l.log.Debugf("Package %s type checked with %v errors", importPath, len(checked.errs))
+		}
+		l.pkgs[importPath] = checked.types
+		return checked.types, nil
+	}
+	pkg, err := l.gc.Import(importPath)
+	if err != nil {
+		return nil, err
+	}
+	l.pkgs[importPath] = pkg
+	return pkg, nil
+}
+
+// localDir returns dir of package, if it is in the same module as source package.
+func (l *sourceModeLoader) localDir(importPath string) (string, bool) {
+	if !l.inModule {
+		return "", false
+	}
+	return l.module.importPathDir(importPath)
+}
+
+// check parses and type checks files in dir as package with importPath.
+// Type errors are returned in package, as it may be still good enough for generation.
+func (l *sourceModeLoader) check(importPath string, dir string, fileNames []string) (*checkedPackage, error) {
+	res := &checkedPackage{}
+	var files []*ast.File
+	for _, name := range fileNames {
+		path := filepath.Join(dir, name)
+		content, err := afero.ReadFile(l.env.fs(), path)
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(l.fset, path, content, parser.ParseComments)
+		if file == nil {
+			return nil, err
+		}
+		var errList scanner.ErrorList
+		if errors.As(err, &errList) {
+			for _, e := range errList {
+				res.errs = append(res.errs, packages.Error{Pos: e.Pos.String(), Msg: e.Msg, Kind: packages.ParseError})
+			}
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	l.loadExports(files)
+	info := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Defs:  map[*ast.Ident]types.Object{},
+		Uses:  map[*ast.Ident]types.Object{},
+	}
+	conf := &types.Config{
+		Importer:    l,
+		FakeImportC: true,
+		Error: func(err error) {
+			var typeErr types.Error
+			if errors.As(err, &typeErr) && !typeErr.Soft {
+				res.errs = append(res.errs, packages.Error{
+					Pos:  typeErr.Fset.Position(typeErr.Pos).String(),
+					Msg:  typeErr.Msg,
+					Kind: packages.TypeError,
+				})
+			}
+		},
+	}
+	// Error is returned via conf.Error.
+	res.types, _ = conf.Check(importPath, l.fset, files, info)
+	resolveInvalidInterfaces(l.log, res.types, files, info)
+	return res, nil
+}
+
+// loadExports finds export data of packages imported by files, that are not type checked from source.
+// Export data is found in one go command run, that builds packages, if they are not in build cache.
+func (l *sourceModeLoader) loadExports(files []*ast.File) {
+	imports := map[string]bool{}
+	for _, file := range files {
+		for _, imp := range file.Imports {
+			importPath, err := strconv.Unquote(imp.Path.Value)
+			if err != nil || importPath == "C" || importPath == "unsafe" {
+				continue
+			}
+			if _, ok := l.exports[importPath]; ok {
+				continue
+			}
+			if _, ok := l.localDir(importPath); ok {
+				continue
+			}
+			imports[importPath] = true
+		}
+	}
+	if len(imports) == 0 {
+		return
+	}
+	importPaths := sortedKeys(imports)
+	for _, importPath := range importPaths {
+		// Failed ones stay empty, so they are not listed again.
+		l.exports[importPath] = ""
+	}
+	l.log.Debugf("Listing export data: %s", strings.Join(importPaths, " "))
+	cmd := exec.CommandContext(l.ctx, "go", append([]string{"list", "-e", "-export", "-f", "{{.ImportPath}}\t{{.Export}}", "--"}, importPaths...)...)
+	cmd.Dir = l.dir
+	cmd.Env = l.env.Env
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	// Output is parsed even on failure, as only some packages may fail to build.
+	out, err := cmd.Output()
+	if err != nil {
+		l.log.Debugf("Export data list failed: %s\n%s", err, stderr)
+	}
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		importPath, export, ok := strings.Cut(s.Text(), "\t")
+		if ok {
+			l.exports[importPath] = export
+		}
+	}
+}
+
+// resolveInvalidInterfaces replaces method sets of pkg interfaces, that have invalid types, with ones built from syntax.
+// Types are invalid, when dependency failed to load or type check. Such type expressions are resolved to
+// named types with the same name and package, so they are printed as written in source.
+func resolveInvalidInterfaces(log *zap.SugaredLogger, pkg *types.Package, files []*ast.File, info *types.Info) {
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				ifaceExpr, ok := typeSpec.Type.(*ast.InterfaceType)
+				if !ok || typeSpec.Assign.IsValid() || typeSpec.TypeParams != nil {
+					continue
+				}
+				obj, ok := info.Defs[typeSpec.Name].(*types.TypeName)
+				if !ok {
+					continue
+				}
+				named, ok := obj.Type().(*types.Named)
+				if !ok || !hasInvalidType(named.Underlying(), map[types.Type]bool{}) {
+					continue
+				}
+				r := &syntaxTypeResolver{pkg: pkg, info: info}
+				iface, err := r.interfaceType(ifaceExpr)
+				if err != nil {
+					log.Warnf("Interface %s has unresolved types: %s", obj.Name(), err)
+					continue
+				}
+				log.Debugf("Interface %s unresolved types are taken from syntax", obj.Name())
+				named.SetUnderlying(iface)
+			}
+		}
+	}
+}
+
+// hasInvalidType returns true, if t has invalid type in it.
+func hasInvalidType(t types.Type, visited map[types.Type]bool) bool {
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+	switch t := t.(type) {
+	case *types.Basic:
+		return t.Kind() == types.Invalid
+	case *types.Pointer:
+		return hasInvalidType(t.Elem(), visited)
+	case *types.Slice:
+		return hasInvalidType(t.Elem(), visited)
+	case *types.Array:
+		return hasInvalidType(t.Elem(), visited)
+	case *types.Chan:
+		return hasInvalidType(t.Elem(), visited)
+	case *types.Map:
+		return hasInvalidType(t.Key(), visited) || hasInvalidType(t.Elem(), visited)
+	case *types.Signature:
+		return hasInvalidType(t.Params(), visited) || hasInvalidType(t.Results(), visited)
+	case *types.Tuple:
+		for i := 0; i < t.Len(); i++ {
+			if hasInvalidType(t.At(i).Type(), visited) {
+				return true
+			}
+		}
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if hasInvalidType(t.Field(i).Type(), visited) {
+				return true
+			}
+		}
+	case *types.Interface:
+		for i := 0; i < t.NumExplicitMethods(); i++ {
+			if hasInvalidType(t.ExplicitMethod(i).Type(), visited) {
+				return true
+			}
+		}
+		for i := 0; i < t.NumEmbeddeds(); i++ {
+			if hasInvalidType(t.EmbeddedType(i), visited) {
+				return true
+			}
+		}
+	}
+	// Named types are valid, even if their underlying types are not, as they are printed by name.
+	return false
+}
+
+// syntaxTypeResolver builds types from syntax, using type checker info, where it is valid.
+type syntaxTypeResolver struct {
+	pkg  *types.Package
+	info *types.Info
+}
+
+func (r *syntaxTypeResolver) interfaceType(expr *ast.InterfaceType) (*types.Interface, error) {
+	var methods []*types.Func
+	var embeddeds []types.Type
+	for _, field := range expr.Methods.List {
+		if len(field.Names) == 0 {
+			t, err := r.typ(field.Type)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := t.Underlying().(*types.Interface); !ok {
+				return nil, fmt.Errorf("embedded interface %s can't be resolved", types.ExprString(field.Type))
+			}
+			embeddeds = append(embeddeds, t)
+			continue
+		}
+		funcType, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			return nil, fmt.Errorf("unexpected method %s type %T", field.Names[0].Name, field.Type)
+		}
+		sig, err := r.signature(funcType)
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", field.Names[0].Name, err)
+		}
+		for _, name := range field.Names {
+			methods = append(methods, types.NewFunc(name.Pos(), r.pkg, name.Name, sig))
+		}
+	}
+	return types.NewInterfaceType(methods, embeddeds).Complete(), nil
+}
+
+func (r *syntaxTypeResolver) signature(expr *ast.FuncType) (*types.Signature, error) {
+	params, variadic, err := r.tuple(expr.Params)
+	if err != nil {
+		return nil, err
+	}
+	results, _, err := r.tuple(expr.Results)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewSignatureType(nil, nil, nil, params, results, variadic), nil
+}
+
+func (r *syntaxTypeResolver) tuple(fields *ast.FieldList) (*types.Tuple, bool, error) {
+	if fields == nil {
+		return nil, false, nil
+	}
+	var vars []*types.Var
+	var variadic bool
+	for _, field := range fields.List {
+		typeExpr := field.Type
+		if ellipsis, ok := typeExpr.(*ast.Ellipsis); ok {
+			typeExpr, variadic = ellipsis.Elt, true
+		}
+		t, err := r.typ(typeExpr)
+		if err != nil {
+			return nil, false, err
+		}
+		if variadic {
+			t = types.NewSlice(t)
+		}
+		if len(field.Names) == 0 {
+			vars = append(vars, types.NewParam(field.Pos(), r.pkg, "", t))
+		}
+		for _, name := range field.Names {
+			vars = append(vars, types.NewParam(name.Pos(), r.pkg, name.Name, t))
+		}
+	}
+	return types.NewTuple(vars...), variadic, nil
+}
+
+func (r *syntaxTypeResolver) typ(expr ast.Expr) (types.Type, error) {
+	if tv, ok := r.info.Types[expr]; ok && tv.IsType() && !hasInvalidType(tv.Type, map[types.Type]bool{}) {
+		return tv.Type, nil
+	}
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return r.typ(expr.X)
+	case *ast.Ident:
+		// Undeclared identifier is printed as is.
+		return unresolvedNamed(r.pkg, expr.Name), nil
+	case *ast.SelectorExpr:
+		x, ok := expr.X.(*ast.Ident)
+		if !ok {
+			break
+		}
+		pkgName, ok := r.info.Uses[x].(*types.PkgName)
+		if !ok {
+			return nil, fmt.Errorf("%s is not imported package", x.Name)
+		}
+		imported := pkgName.Imported()
+		// Name of package that failed to load is guessed from import path, so it is better to use the name from file.
+		return unresolvedNamed(types.NewPackage(imported.Path(), x.Name), expr.Sel.Name), nil
+	case *ast.StarExpr:
+		elem, err := r.typ(expr.X)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewPointer(elem), nil
+	case *ast.ArrayType:
+		elem, err := r.typ(expr.Elt)
+		if err != nil {
+			return nil, err
+		}
+		if expr.Len == nil {
+			return types.NewSlice(elem), nil
+		}
+		tv := r.info.Types[expr.Len]
+		if tv.Value == nil || tv.Value.Kind() != constant.Int {
+			return nil, fmt.Errorf("array length %s can't be resolved", types.ExprString(expr.Len))
+		}
+		length, _ := constant.Int64Val(tv.Value)
+		return types.NewArray(elem, length), nil
+	case *ast.MapType:
+		key, err := r.typ(expr.Key)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := r.typ(expr.Value)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewMap(key, elem), nil
+	case *ast.ChanType:
+		elem, err := r.typ(expr.Value)
+		if err != nil {
+			return nil, err
+		}
+		dir := types.SendRecv
+		switch expr.Dir {
+		case ast.SEND:
+			dir = types.SendOnly
+		case ast.RECV:
+			dir = types.RecvOnly
+		}
+		return types.NewChan(dir, elem), nil
+	case *ast.FuncType:
+		return r.signature(expr)
+	case *ast.InterfaceType:
+		return r.interfaceType(expr)
+	case *ast.StructType:
+		if len(expr.Fields.List) == 0 {
+			return types.NewStruct(nil, nil), nil
+		}
+	}
+	return nil, fmt.Errorf("type %s can't be resolved", types.ExprString(expr))
+}
+
+// unresolvedNamed returns named type, that is printed as name qualified by pkg.
+// Its underlying type is empty interface, as actual one is unknown.
+func unresolvedNamed(pkg *types.Package, name string) *types.Named {
+	return types.NewNamed(types.NewTypeName(token.NoPos, pkg, name, nil), types.NewInterfaceType(nil, nil).Complete(), nil)
+}
+
+// warnPackagesErrors logs loaded packages errors, as generation may fail because of them.
+func warnPackagesErrors(log *zap.SugaredLogger, pkgs []*packages.Package) {
+	if errNum := packagesErrorsNum(pkgs); errNum != 0 {
+		str := printPackagesErrors(pkgs)
+		log.Warnf("Packages loaded with %v errors. Generation may fail, if type information was not able to load.\n%s", errNum, str)
+	}
+}
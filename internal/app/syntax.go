@@ -0,0 +1,141 @@
+package app
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+
+	"github.com/spf13/afero"
+	"go.uber.org/zap"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/skipor/gmg/pkg/gmg"
+)
+
+// loadInterfacesSyntax sets interfaces declarations syntax info, when it is available.
+// Files are parsed by ourselves, for the same reason as in parseGoGenerateCommentFile.
+func loadInterfacesSyntax(log *zap.SugaredLogger, fs afero.Fs, pkgs []*packages.Package, ifaces []gmg.Interface) {
+	parsed := map[string]*ast.File{}
+	fset := token.NewFileSet()
+	for i := range ifaces {
+		iface := &ifaces[i]
+		pkg, obj := lookupInterfaceObject(pkgs, *iface)
+		if obj == nil {
+			log.Debugf("Interface %s.%s declaration object is not found", iface.ImportPath, iface.Name)
+			continue
+		}
+		filename := pkg.Fset.Position(obj.Pos()).Filename
+		if filename == "" {
+			log.Infof("Interface %s.%s declaration position is unknown, so its comments are ignored", iface.ImportPath, iface.Name)
+			continue
+		}
+		file, ok := parsed[filename]
+		if !ok {
+			file = parseDeclarationFile(log, fs, fset, filename)
+			parsed[filename] = file
+		}
+		if file == nil {
+			continue
+		}
+		iface.Syntax = interfaceSyntax(fset, file, iface.Name)
+	}
+}
+
+func lookupInterfaceObject(pkgs []*packages.Package, iface gmg.Interface) (*packages.Package, types.Object) {
+	for _, pkg := range pkgs {
+		if pkg.PkgPath != iface.ImportPath || pkg.Types == nil {
+			continue
+		}
+		obj := pkg.Types.Scope().Lookup(iface.Name)
+		if obj != nil {
+			return pkg, obj
+		}
+	}
+	return nil, nil
+}
+
+func parseDeclarationFile(log *zap.SugaredLogger, fs afero.Fs, fset *token.FileSet, filename string) *ast.File {
+	data, err := afero.ReadFile(fs, filename)
+	if err != nil {
+		log.Warnf("Interface declaration file '%s' read failed, so its comments are ignored: %s", filename, err)
+		return nil
+	}
+	file, err := parser.ParseFile(fset, filename, data, parser.ParseComments)
+	if err != nil {
+		log.Infof("Interface declaration file '%s' parse errors: %s", filename, err)
+	}
+	return file
+}
+
+func interfaceSyntax(fset *token.FileSet, file *ast.File, name string) *gmg.InterfaceSyntax {
+	for _, decl := range file.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.TypeSpec)
+			if spec.Name.Name != name {
+				continue
+			}
+			doc := spec.Doc
+			if doc == nil && len(decl.Specs) == 1 {
+				doc = decl.Doc
+			}
+			s := &gmg.InterfaceSyntax{
+				Doc:     doc,
+				Methods: map[string]*gmg.MethodSyntax{},
+			}
+			ifaceType, ok := spec.Type.(*ast.InterfaceType)
+			if !ok {
+				return s
+			}
+			for _, field := range ifaceType.Methods.List {
+				funcType, ok := field.Type.(*ast.FuncType)
+				if !ok || len(field.Names) == 0 {
+					// Embedded interface.
+					continue
+				}
+				s.Methods[field.Names[0].Name] = &gmg.MethodSyntax{
+					Doc:           field.Doc,
+					ParamComments: paramLineComments(fset, file, field.Names[0], funcType),
+				}
+			}
+			return s
+		}
+	}
+	return nil
+}
+
+// paramLineComments returns comments, that are on the same line with param declaration.
+// Only params declared on separate lines are considered, otherwise it's unclear to which param comment belongs.
+func paramLineComments(fset *token.FileSet, file *ast.File, methodName *ast.Ident, funcType *ast.FuncType) map[int]*ast.CommentGroup {
+	line := func(p token.Pos) int { return fset.Position(p).Line }
+	fields := funcType.Params.List
+	lineFields := map[int]int{line(methodName.Pos()): 1}
+	for _, field := range fields {
+		lineFields[line(field.End())]++
+	}
+	comments := map[int]*ast.CommentGroup{}
+	var paramIndex int
+	for _, field := range fields {
+		names := len(field.Names)
+		if names == 0 {
+			names = 1
+		}
+		fieldLine := line(field.End())
+		if lineFields[fieldLine] == 1 {
+			for _, cg := range file.Comments {
+				if cg.Pos() > field.End() && line(cg.Pos()) == fieldLine {
+					for i := 0; i < names; i++ {
+						comments[paramIndex+i] = cg
+					}
+					break
+				}
+			}
+		}
+		paramIndex += names
+	}
+	return comments
+}
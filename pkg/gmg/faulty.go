@@ -0,0 +1,124 @@
+package gmg
+
+import (
+	"go/types"
+
+	"github.com/iancoleman/strcase"
+	"go.uber.org/zap"
+
+	"github.com/skipor/gmg/pkg/gogen"
+)
+
+var (
+	fmtPkg  = gogen.ImportPath("fmt")
+	randPkg = gogen.ImportPath("math/rand")
+)
+
+func generateFaulty(log *zap.SugaredLogger, f *gogen.File, p generateParams) {
+	typeName := "Faulty" + strcase.ToCamel(p.InterfaceName)
+	g := &faultyGenerator{
+		fileGenerator: newFileGenerator(log, f, p),
+		typeName:      typeName,
+		policyName:    typeName + "Policy",
+		iface:         gogen.ImportPath(p.PackagePath).Ident(p.InterfaceName),
+	}
+	g.generate()
+}
+
+type faultyGenerator struct {
+	*fileGenerator
+	typeName   string
+	policyName string
+	iface      gogen.Ident
+}
+
+func (g *faultyGenerator) generate() {
+	g.L(`
+	// `, g.policyName, ` configures faults, that `, g.typeName, ` injects.
+	// Zero policy injects nothing.
+	type `, g.policyName, ` struct {
+		// ErrorRate is probability in [0, 1] of returning error instead of calling wrapped implementation.
+		ErrorRate float64
+		// Err is injected error. If it is nil, then error with method name is injected.
+		Err error
+		// Latency is added before every call.
+		Latency `, timePkg.Ident("Duration"), `
+		// Override, if set, is called before every call with method name, instead of ErrorRate check.
+		// Returned non-nil error is injected. For methods without error result, returned error is ignored.
+		Override func(method string) error
+	}`)
+
+	g.L(`
+	// New`, g.typeName, ` creates a new `, g.typeName, `, that calls next and injects faults according to policy.
+	func New`, g.typeName, `(next `, g.iface, `, policy `, g.policyName, `) *`, g.typeName, ` {
+		return &`, g.typeName, `{next: next, policy: policy}
+	}`)
+
+	g.L(`
+	// `, g.typeName, ` is a fault injecting decorator of `, g.PackagePath, `.`, g.InterfaceName, `.
+	// Errors are injected only into methods, which last result is error.
+	type `, g.typeName, ` struct {
+		next `, g.iface, `
+		policy `, g.policyName, `
+	}`)
+
+	g.L(`
+	func (`, decoratorReceiver, ` *`, g.typeName, `) fault(method string) error {
+		if `, decoratorReceiver, `.policy.Latency > 0 {
+			`, timePkg.Ident("Sleep"), `(`, decoratorReceiver, `.policy.Latency)
+		}
+		if `, decoratorReceiver, `.policy.Override != nil {
+			return `, decoratorReceiver, `.policy.Override(method)
+		}
+		if `, decoratorReceiver, `.policy.ErrorRate <= 0 || `, randPkg.Ident("Float64"), `() >= `, decoratorReceiver, `.policy.ErrorRate {
+			return nil
+		}
+		if `, decoratorReceiver, `.policy.Err != nil {
+			return `, decoratorReceiver, `.policy.Err
+		}
+		return `, fmtPkg.Ident("Errorf"), `("`, g.InterfaceName, `.%s: injected fault", method)
+	}`)
+	g.L()
+
+	for i, n := 0, g.Interface.NumMethods(); i < n; i++ {
+		g.genMethod(g.Interface.Method(i))
+	}
+}
+
+func (g *faultyGenerator) genMethod(method *types.Func) {
+	scope := g.NewFuncScope()
+	receiver := scope.Declare(decoratorReceiver)
+	sig := method.Type().(*types.Signature)
+	results := sig.Results()
+	g.L(`// `, method.Name(), ` implements `, g.PackagePath, `.`, g.InterfaceName, `.`)
+	paramsNames, resultNames := g.genMethodHead(scope, receiver, g.typeName, method)
+	g.L(" {")
+
+	n := results.Len()
+	if n > 0 && isError(results.At(n-1).Type()) {
+		fault := scope.Declare("err_")
+		g.L(`if `, fault, ` := `, receiver, `.fault("`, method.Name(), `"); `, fault, ` != nil {`)
+		faultResults := append([]string{}, resultNames[:n-1]...)
+		for i, name := range faultResults {
+			result := results.At(i)
+			if noName(result) {
+				g.P(`var `, name, ` `)
+				g.writeType(result.Type())
+				g.L()
+			}
+		}
+		g.genReturn(append(faultResults, fault))
+		g.L(`}`)
+	} else {
+		g.L(`_ = `, receiver, `.fault("`, method.Name(), `")`)
+	}
+
+	if n > 0 {
+		g.P(`return `)
+	}
+	g.P(receiver, `.next.`, method.Name(), `(`)
+	g.genCallArgs(sig, paramsNames)
+	g.L(`)`)
+	g.L("}")
+	g.L()
+}
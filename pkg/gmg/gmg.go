@@ -2,8 +2,13 @@ package gmg
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"go/ast"
 	"go/types"
+	"strings"
+	"text/template"
 
 	"github.com/iancoleman/strcase"
 	"go.uber.org/zap"
@@ -28,6 +33,49 @@ type Interface struct {
 	Type *types.Interface
 	// ImportPath is to add interface source to generated godoc.
 	ImportPath string
+	// Syntax is optional interface declaration source info.
+	// It is required only for generation kinds that use comments, for example KindLogging.
+	Syntax *InterfaceSyntax
+	// MockName is optional name of generated mock type. 'Mock<Name>' is used, when it is empty.
+	// It is used only by KindMock.
+	MockName string
+}
+
+// MethodSetHash returns stable hash of interface method set, with fully qualified types.
+// It is changed, only when interface methods or types they use are changed, so generated code should be changed too.
+// Comments and declaration order are not hashed.
+func (i Interface) MethodSetHash() string {
+	h := sha256.New()
+	// Methods are sorted by name.
+	for j := 0; j < i.Type.NumMethods(); j++ {
+		m := i.Type.Method(j)
+		_, _ = fmt.Fprintf(h, "%s %s\n", m.Name(), types.TypeString(m.Type(), nil))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:methodSetHashLen]
+}
+
+// methodSetHashLen is length of hex method set hash, that is enough to not collide in practice, but short to be read.
+const methodSetHashLen = 16
+
+// InterfaceSyntax is interface declaration source info.
+type InterfaceSyntax struct {
+	Doc *ast.CommentGroup
+	// Methods contains explicitly declared methods, but not embedded ones.
+	Methods map[string]*MethodSyntax
+}
+
+// MethodSyntax is interface method declaration source info.
+type MethodSyntax struct {
+	Doc *ast.CommentGroup
+	// ParamComments contains comments that are on the same line as param declaration, by param index.
+	ParamComments map[int]*ast.CommentGroup
+}
+
+func (s *InterfaceSyntax) method(name string) *MethodSyntax {
+	if s == nil {
+		return nil
+	}
+	return s.Methods[name]
 }
 
 type GenerateFileParams struct {
@@ -36,36 +84,114 @@ type GenerateFileParams struct {
 	PackageName string
 	Interfaces  []Interface
 	Options     GenerateOptions
+	// Command is optional gmg command line args, that reproduces file, when run in file dir.
+	// It is put to generated file header, so file can be regenerated without knowing how it was generated.
+	Command []string
 }
 
 type GenerateOptions struct {
-	// TODO:
+	// Kind is kind of generated code. KindMock by default.
+	Kind Kind
+	// Template is used instead of Kind, when set. See ParseTemplate for details.
+	Template *template.Template
+	// SharedRuntime is set, when mock call wrappers should be aliases of github.com/skipor/gmg/pkg/gmgrt generic types,
+	// instead of dedicated types. It requires Go 1.18.
+	SharedRuntime bool
+	// GoVersion is Go language version of generated file module, like "1.21", that is set by go directive of its go.mod.
+	// Newer language features, like 'any', are used in generated code, only when version permits them.
+	// Empty means that version is unknown, so code that compiles with any Go version is generated.
+	GoVersion string
 }
 
-func (g *GMG) GenerateFile(p GenerateFileParams) {
-	file := g.gen.NewFile(p.FilePath, gogen.ImportPath(p.ImportPath))
-	genFileHead(file, p.PackageName, p.Interfaces)
+// RequiredGoVersion returns minimal Go version, that code generated with options requires, and that is not implied by GoVersion.
+// Empty, if any version is fine, or GoVersion is set, as generation fails, when it is lower than required.
+func (o GenerateOptions) RequiredGoVersion() string {
+	if o.GoVersion != "" {
+		return ""
+	}
+	version, _ := o.requiredGoVersion()
+	return version
+}
+
+// requiredGoVersion returns minimal Go version, and feature that requires it, if generated code can't be compiled by any version.
+func (o GenerateOptions) requiredGoVersion() (version string, feature string) {
+	switch {
+	case o.Template == nil && o.Kind == KindLogging:
+		return slogGoVersion, "logging kind"
+	case o.SharedRuntime:
+		return genericsGoVersion, "shared runtime"
+	case goVersionAtLeast(o.GoVersion, genericsGoVersion):
+		return genericsGoVersion, "any"
+	}
+	return "", ""
+}
+
+// Kind is kind of generated code.
+type Kind string
+
+const (
+	// KindMock is GoMock of interface.
+	KindMock Kind = "mock"
+	// KindLogging is interface decorator, that logs method calls via *slog.Logger. It requires Go 1.21.
+	KindLogging Kind = "logging"
+	// KindFaulty is interface decorator, that injects errors and latency into calls of real implementation.
+	KindFaulty Kind = "faulty"
+)
 
-	// TODO(skipor): import all packages used in args and results
+// Kinds returns all supported kinds.
+func Kinds() []Kind {
+	return []Kind{KindMock, KindLogging, KindFaulty}
+}
+
+func (k Kind) String() string { return string(k) }
+
+func (g *GMG) GenerateFile(p GenerateFileParams) error {
+	opts := p.Options
+	if required, feature := opts.requiredGoVersion(); required != "" && opts.GoVersion != "" && !goVersionAtLeast(opts.GoVersion, required) {
+		return fmt.Errorf("%s requires Go %s, but module go version is %s. Update go directive in go.mod",
+			feature, required, opts.GoVersion)
+	}
+	emptyInterface := "interface{}"
+	if goVersionAtLeast(opts.GoVersion, genericsGoVersion) {
+		emptyInterface = "any"
+	}
+	file := g.gen.NewFile(p.FilePath, gogen.ImportPath(p.ImportPath))
+	genFileHead(file, p)
 
+	if p.Options.Template != nil {
+		return generateTemplate(file, p.Options.Template, p)
+	}
 	for _, iface := range p.Interfaces {
-		generate(g.log, file, generateParams{
-			InterfaceName: iface.Name,
-			Interface:     iface.Type,
-			PackagePath:   iface.ImportPath,
-		})
+		gp := generateParams{
+			InterfaceName:  iface.Name,
+			MockName:       iface.MockName,
+			Interface:      iface.Type,
+			PackagePath:    iface.ImportPath,
+			Syntax:         iface.Syntax,
+			SharedRuntime:  p.Options.SharedRuntime,
+			EmptyInterface: emptyInterface,
+		}
+		switch p.Options.Kind {
+		case KindLogging:
+			generateLogging(g.log, file, gp)
+		case KindFaulty:
+			generateFaulty(g.log, file, gp)
+		default:
+			generate(g.log, file, gp)
+		}
 	}
+	return nil
 }
 
 func (g *GMG) Files() []*gogen.File {
 	return g.gen.Files()
 }
 
-func genFileHead(f *gogen.File, packageName string, interfaces []Interface) {
-	f.L(`// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.`)
-	f.P(`// Source: `)
+func genFileHead(f *gogen.File, p GenerateFileParams) {
+	f.L(generatedHeader)
+	f.P(sourcePrefix)
 	var prevImportPath string
-	for i, iface := range interfaces {
+	for i, iface := range p.Interfaces {
 		if iface.ImportPath == prevImportPath {
 			f.P(",", iface.Name)
 			continue
@@ -77,34 +203,48 @@ func genFileHead(f *gogen.File, packageName string, interfaces []Interface) {
 		f.P(iface.ImportPath, ".", iface.Name)
 	}
 	f.L()
+	genMethodSetHashes(f, p)
+	genCommand(f, p)
+	genRequires(f, p)
+	genBodyHash(f)
 	f.L()
-	f.L("package ", packageName)
+	f.L("package ", p.PackageName)
 	f.L()
-
-	f.Import("reflect")
-	f.Import("github.com/golang/mock/gomock")
 }
 
 type generateParams struct {
 	InterfaceName string
+	MockName      string
 	Interface     *types.Interface
 	PackagePath   string
+	Syntax        *InterfaceSyntax
+	SharedRuntime bool
+	// EmptyInterface is 'any' or 'interface{}', depending on Go version.
+	EmptyInterface string
 }
 
 func generate(log *zap.SugaredLogger, f *gogen.File, p generateParams) {
-	mockName := "Mock" + strcase.ToCamel(p.InterfaceName)
-	recorderName := mockName + "MockRecorder"
-	fg := &fileGenerator{
+	f.Import("reflect")
+	f.Import("github.com/golang/mock/gomock")
+	mockName := p.MockName
+	if mockName == "" {
+		mockName = "Mock" + strcase.ToCamel(p.InterfaceName)
+	}
+	fg := newFileGenerator(log, f, p)
+	fg.mockName = mockName
+	fg.recorderName = mockName + "MockRecorder"
+	fg.generate()
+}
+
+func newFileGenerator(log *zap.SugaredLogger, f *gogen.File, p generateParams) *fileGenerator {
+	return &fileGenerator{
 		File:           f,
 		generateParams: p,
 		log:            log,
 		qualifier: func(pkg *types.Package) string {
 			return f.QualifiedImportPath(gogen.ImportPath(pkg.Path()))
 		},
-		mockName:     mockName,
-		recorderName: recorderName,
 	}
-	fg.generate()
 }
 
 type fileGenerator struct {
@@ -157,18 +297,14 @@ func (g *fileGenerator) genMockMethod(method *types.Func) {
 	sig := method.Type().(*types.Signature)
 	results := sig.Results()
 	g.L(`// `, method.Name(), ` implements mocked interface.`)
-	g.P(`func (`, receiver, ` *`, g.mockName, `) `, method.Name(), `(`)
-	paramsNames := g.genMockMethodParams(scope, sig)
-	g.P(")")
-
-	resultNames := g.genMockMethodFuncResults(scope, results)
+	paramsNames, resultNames := g.genMethodHead(scope, receiver, g.mockName, method)
 	g.L(" {")
 
 	res := scope.Declare("res_")
 	lastParam := len(paramsNames) - 1
 	g.L(receiver, `.ctrl.T.Helper()`)
 	if sig.Variadic() {
-		g.P(varArg, ` := []interface{}{`)
+		g.P(varArg, ` := []`, g.EmptyInterface, `{`)
 		for i, name := range paramsNames[:lastParam] {
 			if i != 0 {
 				g.P(", ")
@@ -205,6 +341,54 @@ func (g *fileGenerator) genMockMethod(method *types.Func) {
 		g.L(`)`)
 	}
 
+	g.genReturn(resultNames)
+	g.L("}")
+	g.L()
+}
+
+// genMethodHead writes method declaration without body.
+func (g *fileGenerator) genMethodHead(scope *gogen.Scope, receiver string, typeName string, method *types.Func) (paramsNames []string, resultNames []string) {
+	sig := method.Type().(*types.Signature)
+	g.P(`func (`, receiver, ` *`, typeName, `) `, method.Name(), `(`)
+	paramsNames = g.genMockMethodParams(scope, sig)
+	g.P(")")
+	resultNames = g.genMockMethodFuncResults(scope, sig.Results())
+	return paramsNames, resultNames
+}
+
+// genCallArgs writes arguments of call with method signature.
+func (g *fileGenerator) genCallArgs(sig *types.Signature, paramsNames []string) {
+	for i, name := range paramsNames {
+		if i != 0 {
+			g.P(", ")
+		}
+		g.P(name)
+		if sig.Variadic() && i == len(paramsNames)-1 {
+			g.P("...")
+		}
+	}
+}
+
+// genResultsAssign writes left side of assignment of method call results to result names.
+func (g *fileGenerator) genResultsAssign(results *types.Tuple, resultNames []string) {
+	if len(resultNames) == 0 {
+		return
+	}
+	declare := false
+	for i := 0; i < results.Len(); i++ {
+		if noName(results.At(i)) {
+			declare = true
+		}
+	}
+	g.P(strings.Join(resultNames, ", "))
+	if declare {
+		g.P(" := ")
+	} else {
+		g.P(" = ")
+	}
+}
+
+func (g *fileGenerator) genReturn(resultNames []string) {
 	g.P("return")
 	for i, resultName := range resultNames {
 		if i != 0 {
@@ -213,8 +397,6 @@ func (g *fileGenerator) genMockMethod(method *types.Func) {
 		g.P(" ", resultName)
 	}
 	g.L()
-	g.L("}")
-	g.L()
 }
 
 func noName(v *types.Var) bool {
@@ -331,7 +513,7 @@ func (g *fileGenerator) genRecorderMethod(method *types.Func) {
 		if len(paramsNames) == 1 {
 			varArg = paramsNames[0]
 		} else {
-			g.P(varArg, ` := append([]interface{}{`)
+			g.P(varArg, ` := append([]`, g.EmptyInterface, `{`)
 			for i, name := range paramsNames[:lastParam] {
 				if i != 0 {
 					g.P(", ")
@@ -351,7 +533,12 @@ func (g *fileGenerator) genRecorderMethod(method *types.Func) {
 	}
 	g.L(")")
 
-	g.L("return ", callWrapperName, `{`, callVarName, `}`)
+	if g.usesSharedCallWrapper(sig) {
+		// Field is keyed, as go vet requires that for struct types from other packages.
+		g.L("return ", callWrapperName, `{Call: `, callVarName, `}`)
+	} else {
+		g.L("return ", callWrapperName, `{`, callVarName, `}`)
+	}
 	g.L(`}`)
 	g.L()
 	g.genGomockCallWrapper(callWrapperName, method.Type().(*types.Signature))
@@ -371,12 +558,26 @@ func (g *fileGenerator) genRecorderMethodParams(sig *types.Signature, scope *gog
 		if sig.Variadic() && i == l-1 {
 			g.P("...")
 		}
-		g.P("interface{}")
+		g.P(g.EmptyInterface)
 	}
 	return paramNames
 }
 
+const gmgrtPkg gogen.ImportPath = "github.com/skipor/gmg/pkg/gmgrt"
+
+// sharedRuntimeMaxResults is max number of method results, that gmgrt has call wrapper for.
+const sharedRuntimeMaxResults = 4
+
+// usesSharedCallWrapper returns true, when call wrapper of method with sig is alias of gmgrt type.
+func (g *fileGenerator) usesSharedCallWrapper(sig *types.Signature) bool {
+	return g.SharedRuntime && sig.Results().Len() <= sharedRuntimeMaxResults
+}
+
 func (g *fileGenerator) genGomockCallWrapper(callWrapperName string, sig *types.Signature) {
+	if g.usesSharedCallWrapper(sig) {
+		g.genSharedCallWrapper(callWrapperName, sig)
+		return
+	}
 	g.L(`
 	// `, callWrapperName, ` is type safe wrapper of *gomock.Call.
 	type `, callWrapperName, ` struct{ *gomock.Call }
@@ -446,6 +647,28 @@ func (g *fileGenerator) genGomockCallWrapper(callWrapperName string, sig *types.
 	}
 }
 
+// genSharedCallWrapper generates call wrapper as alias of gmgrt.CallN instantiation.
+func (g *fileGenerator) genSharedCallWrapper(callWrapperName string, sig *types.Signature) {
+	results := sig.Results()
+	g.P(`
+	// `, callWrapperName, ` is type safe wrapper of *gomock.Call.
+	type `, callWrapperName, ` = `, gmgrtPkg.Ident(fmt.Sprintf("Call%v", results.Len())), `[func(`)
+	g.genMockMethodParams(g.NewFuncScope(), sig)
+	g.P(`)`)
+	g.genMockMethodFuncResults(g.NewFuncScope(), results)
+	if results.Len() > 0 {
+		g.P(`, func(`)
+		g.genMockMethodParams(g.NewFuncScope(), sig)
+		g.P(`)`)
+	}
+	for i := 0; i < results.Len(); i++ {
+		g.P(`, `)
+		g.writeType(results.At(i).Type())
+	}
+	g.L(`]`)
+	g.L()
+}
+
 func (g *fileGenerator) writeType(t types.Type) {
 	types.WriteType(g.Buffer(), t, g.qualifier)
 }
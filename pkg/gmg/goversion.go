@@ -0,0 +1,39 @@
+package gmg
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// genericsGoVersion is Go version, that introduced generics and 'any'.
+	genericsGoVersion = "1.18"
+	// slogGoVersion is Go version, that introduced log/slog, that logging decorators use.
+	slogGoVersion = "1.21"
+)
+
+// goVersionAtLeast returns true, if Go version like "1.21", "1.21.0" or "1.21rc1" is not less than min, like "1.18".
+// False is returned for unknown version.
+func goVersionAtLeast(version string, min string) bool {
+	major, minor, ok := parseGoVersion(version)
+	if !ok {
+		return false
+	}
+	minMajor, minMinor, _ := parseGoVersion(min)
+	return major > minMajor || major == minMajor && minor >= minMinor
+}
+
+func parseGoVersion(version string) (major int, minor int, ok bool) {
+	majorStr, rest, _ := strings.Cut(strings.TrimPrefix(version, "go"), ".")
+	major, err := strconv.Atoi(majorStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	end := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsDigit(r) })
+	if end < 0 {
+		end = len(rest)
+	}
+	minor, _ = strconv.Atoi(rest[:end])
+	return major, minor, true
+}
@@ -0,0 +1,208 @@
+package gmg
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/skipor/gmg/pkg/gogen"
+)
+
+const (
+	generatedHeader = "// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT."
+	sourcePrefix    = "// Source: "
+	hashPrefix      = "// Method set hash: "
+	commandPrefix   = "// Command: gmg "
+	// versionPrefix is prefix of gmg version line, that was written by previous gmg versions.
+	versionPrefix  = "// Version: "
+	requiresPrefix = "// Requires: go"
+	bodyHashPrefix = "// Body hash: "
+)
+
+// bodyHashPlaceholder is written instead of body hash, that is known only after file render.
+var bodyHashPlaceholder = strings.Repeat("0", methodSetHashLen)
+
+// Source is interface, that file was generated from.
+type Source struct {
+	ImportPath string
+	Name       string
+	// Hash is Interface.MethodSetHash. It is empty for files generated by old gmg versions.
+	Hash string
+}
+
+func (s Source) String() string { return s.ImportPath + "." + s.Name }
+
+// Header is info from header of file generated by GMG.
+type Header struct {
+	Sources []Source
+	// Command is gmg command line args, that reproduces file, when run in file dir.
+	// It is empty for files generated by old gmg versions.
+	Command []string
+	// Requires is minimal Go version, like "1.18", that generated code requires beyond its module go directive.
+	// Empty, if any version is fine, or go directive is enough.
+	Requires string
+	// BodyHash is BodyHash of file content, when it was generated. Empty for files generated by old gmg versions.
+	// It differs from actual BodyHash, when file was edited after generation.
+	BodyHash string
+}
+
+// ParseHeader returns info from header of file generated by GMG.
+// False is returned, if content is not file generated by GMG.
+func ParseHeader(content []byte) (Header, bool) {
+	s := bufio.NewScanner(bytes.NewReader(content))
+	if !s.Scan() || s.Text() != generatedHeader {
+		return Header{}, false
+	}
+	if !s.Scan() || !strings.HasPrefix(s.Text()+" ", sourcePrefix) {
+		return Header{}, false
+	}
+	var h Header
+	var ok bool
+	h.Sources, ok = parseSources(strings.TrimSpace(strings.TrimPrefix(s.Text(), strings.TrimSpace(sourcePrefix))))
+	if !ok {
+		return Header{}, false
+	}
+	for s.Scan() {
+		line := s.Text()
+		switch {
+		case strings.HasPrefix(line, hashPrefix):
+			parseHashes(h.Sources, strings.TrimPrefix(line, hashPrefix))
+		case strings.HasPrefix(line, commandPrefix):
+			h.Command, ok = SplitCommand(strings.TrimPrefix(line, commandPrefix))
+			if !ok {
+				return Header{}, false
+			}
+		case strings.HasPrefix(line, versionPrefix):
+			// Version is not written anymore, so new gmg releases don't change every generated file.
+		case strings.HasPrefix(line, requiresPrefix):
+			h.Requires = strings.TrimPrefix(line, requiresPrefix)
+		case strings.HasPrefix(line, bodyHashPrefix):
+			h.BodyHash = strings.TrimPrefix(line, bodyHashPrefix)
+		default:
+			return h, true
+		}
+	}
+	return h, true
+}
+
+func parseSources(line string) ([]Source, bool) {
+	if line == "" {
+		return nil, true
+	}
+	var sources []Source
+	for _, pkgSources := range strings.Split(line, " ;") {
+		// Import path may contain dots, but interface names can't.
+		dot := strings.LastIndex(pkgSources, ".")
+		if dot < 0 {
+			return nil, false
+		}
+		importPath := pkgSources[:dot]
+		for _, name := range strings.Split(pkgSources[dot+1:], ",") {
+			sources = append(sources, Source{ImportPath: importPath, Name: name})
+		}
+	}
+	return sources, true
+}
+
+// parseHashes sets hashes of sources, that are listed as 'Name=hash' in the same order.
+func parseHashes(sources []Source, line string) {
+	for i, field := range strings.Fields(line) {
+		split := strings.SplitN(field, "=", 2)
+		if i >= len(sources) || len(split) != 2 || sources[i].Name != split[0] {
+			return
+		}
+		sources[i].Hash = split[1]
+	}
+}
+
+func genMethodSetHashes(f *gogen.File, p GenerateFileParams) {
+	if len(p.Interfaces) == 0 {
+		return
+	}
+	f.P(hashPrefix)
+	for i, iface := range p.Interfaces {
+		if i != 0 {
+			f.P(" ")
+		}
+		f.P(iface.Name, "=", iface.MethodSetHash())
+	}
+	f.L()
+}
+
+func genCommand(f *gogen.File, p GenerateFileParams) {
+	if len(p.Command) != 0 {
+		f.L(commandPrefix, JoinCommand(p.Command))
+	}
+}
+
+// genBodyHash records hash of code after header, so files edited after generation can be detected.
+// Hash is put on render, as formatted code is hashed.
+func genBodyHash(f *gogen.File) {
+	f.L(bodyHashPrefix, bodyHashPlaceholder)
+	f.OnRender(func(content []byte) []byte {
+		return bytes.Replace(content, []byte(bodyHashPrefix+bodyHashPlaceholder), []byte(bodyHashPrefix+BodyHash(content)), 1)
+	})
+}
+
+// BodyHash returns hash of generated file code after header, starting from package clause.
+func BodyHash(content []byte) string {
+	if i := bytes.Index(content, []byte("\npackage ")); i >= 0 {
+		content = content[i+1:]
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])[:methodSetHashLen]
+}
+
+// genRequires records Go version, that generated code requires, when it is not implied by module go directive.
+func genRequires(f *gogen.File, p GenerateFileParams) {
+	if version := p.Options.RequiredGoVersion(); version != "" {
+		f.L(requiresPrefix, version)
+	}
+}
+
+// JoinCommand joins args to single line, quoting ones that are empty, or contain spaces or quotes.
+// Result is split back by SplitCommand.
+func JoinCommand(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = arg
+		if arg == "" || strings.IndexFunc(arg, func(r rune) bool { return unicode.IsSpace(r) || r == '"' }) >= 0 {
+			quoted[i] = strconv.Quote(arg)
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// SplitCommand splits line joined by JoinCommand, or '//go:generate' directive args.
+// Args are split like 'go generate' does: by spaces and tabs, but double-quoted Go strings are single arg.
+// False is returned, when line contains invalid quoted string.
+func SplitCommand(line string) ([]string, bool) {
+	var args []string
+	for line = strings.Trim(line, " \t"); line != ""; line = strings.TrimLeft(line, " \t") {
+		if line[0] != '"' {
+			end := strings.IndexAny(line, " \t")
+			if end < 0 {
+				end = len(line)
+			}
+			args = append(args, line[:end])
+			line = line[end:]
+			continue
+		}
+		quoted, err := strconv.QuotedPrefix(line)
+		if err != nil {
+			return nil, false
+		}
+		arg, err := strconv.Unquote(quoted)
+		if err != nil {
+			panic(fmt.Sprintf("quoted prefix unquote failed: %s", err))
+		}
+		args = append(args, arg)
+		line = line[len(quoted):]
+	}
+	return args, true
+}
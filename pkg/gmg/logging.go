@@ -0,0 +1,186 @@
+package gmg
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"go.uber.org/zap"
+
+	"github.com/skipor/gmg/pkg/gogen"
+)
+
+const (
+	decoratorReceiver = "d_"
+	// redactDirective marks method params that values should not be logged.
+	// It may be put in method doc comment, optionally followed by param names: `//gmg:redact password token`.
+	// Without param names all method params are redacted.
+	// Also, it may be put in the end of param declaration line.
+	redactDirective = "//gmg:redact"
+	redactedValue   = "REDACTED"
+)
+
+var (
+	slogPkg    = gogen.ImportPath("log/slog")
+	timePkg    = gogen.ImportPath("time")
+	contextPkg = gogen.ImportPath("context")
+)
+
+func generateLogging(log *zap.SugaredLogger, f *gogen.File, p generateParams) {
+	g := &loggingGenerator{
+		fileGenerator: newFileGenerator(log, f, p),
+		typeName:      "Logging" + strcase.ToCamel(p.InterfaceName),
+		iface:         gogen.ImportPath(p.PackagePath).Ident(p.InterfaceName),
+	}
+	g.generate()
+}
+
+type loggingGenerator struct {
+	*fileGenerator
+	typeName string
+	iface    gogen.Ident
+}
+
+func (g *loggingGenerator) generate() {
+	g.L(`
+	// New`, g.typeName, ` creates a new `, g.typeName, `, that calls next and logs calls to log.
+	func New`, g.typeName, `(next `, g.iface, `, log *`, slogPkg.Ident("Logger"), `) *`, g.typeName, ` {
+		return &`, g.typeName, `{next: next, log: log}
+	}`)
+
+	g.L(`
+	// `, g.typeName, ` is a logging decorator of `, g.PackagePath, `.`, g.InterfaceName, `.
+	// Successful calls are logged with info level, and calls that returned non-nil error with error level.
+	type `, g.typeName, ` struct {
+		next `, g.iface, `
+		log *`, slogPkg.Ident("Logger"), `
+	}`)
+	g.L()
+
+	for i, n := 0, g.Interface.NumMethods(); i < n; i++ {
+		g.genMethod(g.Interface.Method(i))
+	}
+}
+
+func (g *loggingGenerator) genMethod(method *types.Func) {
+	scope := g.NewFuncScope()
+	receiver := scope.Declare(decoratorReceiver)
+	sig := method.Type().(*types.Signature)
+	params := sig.Params()
+	results := sig.Results()
+	g.L(`// `, method.Name(), ` implements `, g.PackagePath, `.`, g.InterfaceName, `.`)
+	paramsNames, resultNames := g.genMethodHead(scope, receiver, g.typeName, method)
+	g.L(" {")
+
+	start := scope.Declare("start_")
+	attrs := scope.Declare("attrs_")
+	level := scope.Declare("level_")
+	g.L(start, ` := `, timePkg.Ident("Now"), `()`)
+	g.genResultsAssign(results, resultNames)
+	g.P(receiver, `.next.`, method.Name(), `(`)
+	g.genCallArgs(sig, paramsNames)
+	g.L(`)`)
+
+	redacted := redactedParams(g.Syntax.method(method.Name()), params)
+	ctx := []interface{}{contextPkg.Ident("Background"), "()"}
+	g.L(attrs, ` := []`, slogPkg.Ident("Attr"), `{`)
+	for i, name := range paramsNames {
+		param := params.At(i)
+		if i == 0 && isContext(param.Type()) {
+			ctx = []interface{}{name}
+			continue
+		}
+		if redacted[i] {
+			g.L(slogPkg.Ident("String"), `("`, name, `", "`, redactedValue, `"),`)
+			continue
+		}
+		g.L(slogPkg.Ident("Any"), `("`, name, `", `, name, `),`)
+	}
+	errResult := -1
+	if n := results.Len(); n > 0 && isError(results.At(n-1).Type()) {
+		errResult = n - 1
+	}
+	for i, name := range resultNames {
+		if i == errResult {
+			continue
+		}
+		g.L(slogPkg.Ident("Any"), `("`, name, `", `, name, `),`)
+	}
+	g.L(slogPkg.Ident("Duration"), `("duration", `, timePkg.Ident("Since"), `(`, start, `)),`)
+	g.L(`}`)
+	g.L(level, ` := `, slogPkg.Ident("LevelInfo"))
+	if errResult >= 0 {
+		errName := resultNames[errResult]
+		g.L(`if `, errName, ` != nil {
+			`, level, ` = `, slogPkg.Ident("LevelError"), `
+			`, attrs, ` = append(`, attrs, `, `, slogPkg.Ident("Any"), `("error", `, errName, `))
+		}`)
+	}
+	g.P(receiver, `.log.LogAttrs(`)
+	g.P(ctx...)
+	g.L(`, `, level, `, "`, g.InterfaceName, `.`, method.Name(), `", `, attrs, `...)`)
+
+	g.genReturn(resultNames)
+	g.L("}")
+	g.L()
+}
+
+// redactedParams returns indexes of method params, that marked with redactDirective.
+func redactedParams(ms *MethodSyntax, params *types.Tuple) map[int]bool {
+	redacted := map[int]bool{}
+	if ms == nil {
+		return redacted
+	}
+	for i, cg := range ms.ParamComments {
+		if _, ok := directive(cg, redactDirective); ok {
+			redacted[i] = true
+		}
+	}
+	args, ok := directive(ms.Doc, redactDirective)
+	if !ok {
+		return redacted
+	}
+	names := strings.Fields(strings.ReplaceAll(args, ",", " "))
+	for i := 0; i < params.Len(); i++ {
+		if len(names) == 0 {
+			redacted[i] = true
+			continue
+		}
+		for _, name := range names {
+			if params.At(i).Name() == name {
+				redacted[i] = true
+			}
+		}
+	}
+	return redacted
+}
+
+// directive returns arguments of comment directive like `//gmg:name arg1 arg2`, if comments group contains it.
+func directive(cg *ast.CommentGroup, name string) (string, bool) {
+	if cg == nil {
+		return "", false
+	}
+	for _, c := range cg.List {
+		if c.Text == name {
+			return "", true
+		}
+		if strings.HasPrefix(c.Text, name+" ") {
+			return strings.TrimSpace(strings.TrimPrefix(c.Text, name)), true
+		}
+	}
+	return "", false
+}
+
+func isContext(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == contextPkg.String() && obj.Name() == "Context"
+}
+
+func isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
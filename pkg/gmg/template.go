@@ -0,0 +1,211 @@
+package gmg
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+	"text/template"
+
+	"github.com/iancoleman/strcase"
+
+	"github.com/skipor/gmg/pkg/gogen"
+)
+
+// ParseTemplate parses template of generated code, that is executed with TemplateData.
+// Generated file head with package clause and imports are added by GMG, so template should contain only declarations.
+//
+// Template functions:
+//
+//	import "path/to/pkg" - imports package and returns its name in generated file.
+//	comment "text" - returns text as Go comment lines, for example to put method docs.
+//	camel, lowerCamel, snake - change case of string.
+func ParseTemplate(name string, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs(nil)).Parse(text)
+}
+
+func templateFuncs(f *gogen.File) template.FuncMap {
+	return template.FuncMap{
+		"import": func(path string) string {
+			if f == nil {
+				panic("template is not bound to file")
+			}
+			return f.QualifiedImportPath(gogen.ImportPath(path))
+		},
+		"comment":    comment,
+		"camel":      strcase.ToCamel,
+		"lowerCamel": strcase.ToLowerCamel,
+		"snake":      strcase.ToSnake,
+	}
+}
+
+func comment(text string) string {
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace("// " + line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TemplateData is data that template is executed with.
+type TemplateData struct {
+	// PackageName is generated file package name.
+	PackageName string
+	// ImportPath is generated file package import path.
+	ImportPath string
+	Interfaces []TemplateInterface
+}
+
+// TemplateInterface is interface model for templates.
+type TemplateInterface struct {
+	Name       string
+	ImportPath string
+	// Type is interface type name, qualified in generated file.
+	Type TemplateType
+	// Doc is interface doc comment text. It is empty, if interface declaration source is not available.
+	Doc     string
+	Methods []TemplateMethod
+}
+
+// TemplateMethod is interface method model for templates.
+type TemplateMethod struct {
+	Name string
+	// Doc is method doc comment text. It is empty, if interface declaration source is not available.
+	Doc     string
+	Params  []TemplateVar
+	Results []TemplateVar
+	// Variadic is true, if last param is variadic. Its type is slice in that case.
+	Variadic bool
+}
+
+// TemplateVar is method param or result model for templates.
+type TemplateVar struct {
+	// Name is unique in method scope name. Unnamed params and results get generated names.
+	Name string
+	// Type is qualified in generated file type.
+	Type TemplateType
+	// IsError is true for result of error type.
+	IsError bool
+	// IsContext is true for param of context.Context type.
+	IsContext bool
+}
+
+// TemplateType is Go type that adds imports of referenced packages to generated file, when it is printed.
+type TemplateType struct {
+	str func() string
+}
+
+func (t TemplateType) String() string { return t.str() }
+
+// ParamsDecl returns method params declaration, like `a int, bs ...string`.
+func (m TemplateMethod) ParamsDecl() string {
+	var decls []string
+	for i, p := range m.Params {
+		typ := p.Type.String()
+		if m.Variadic && i == len(m.Params)-1 {
+			typ = "..." + strings.TrimPrefix(typ, "[]")
+		}
+		decls = append(decls, p.Name+" "+typ)
+	}
+	return strings.Join(decls, ", ")
+}
+
+// ResultsDecl returns method results declaration with names, like `(a int, err error)`, or empty string if there are no results.
+func (m TemplateMethod) ResultsDecl() string {
+	if len(m.Results) == 0 {
+		return ""
+	}
+	var decls []string
+	for _, r := range m.Results {
+		decls = append(decls, r.Name+" "+r.Type.String())
+	}
+	return "(" + strings.Join(decls, ", ") + ")"
+}
+
+// CallArgs returns method call arguments, like `a, bs...`.
+func (m TemplateMethod) CallArgs() string {
+	var args []string
+	for _, p := range m.Params {
+		args = append(args, p.Name)
+	}
+	if m.Variadic {
+		args[len(args)-1] += "..."
+	}
+	return strings.Join(args, ", ")
+}
+
+// ResultNames returns method result names, like `a, err`.
+func (m TemplateMethod) ResultNames() string {
+	var names []string
+	for _, r := range m.Results {
+		names = append(names, r.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func generateTemplate(f *gogen.File, tmpl *template.Template, p GenerateFileParams) error {
+	tmpl, err := tmpl.Clone()
+	if err != nil {
+		return fmt.Errorf("template clone: %w", err)
+	}
+	tmpl.Funcs(templateFuncs(f))
+	data := TemplateData{
+		PackageName: p.PackageName,
+		ImportPath:  p.ImportPath,
+	}
+	qualifier := func(pkg *types.Package) string {
+		return f.QualifiedImportPath(gogen.ImportPath(pkg.Path()))
+	}
+	typeString := func(t types.Type) TemplateType {
+		return TemplateType{str: func() string { return types.TypeString(t, qualifier) }}
+	}
+	for _, iface := range p.Interfaces {
+		ident := gogen.ImportPath(iface.ImportPath).Ident(iface.Name)
+		ti := TemplateInterface{
+			Name:       iface.Name,
+			ImportPath: iface.ImportPath,
+			Type:       TemplateType{str: func() string { return f.QualifiedIdent(ident) }},
+		}
+		if iface.Syntax != nil {
+			ti.Doc = strings.TrimSuffix(iface.Syntax.Doc.Text(), "\n")
+		}
+		for i, n := 0, iface.Type.NumMethods(); i < n; i++ {
+			method := iface.Type.Method(i)
+			sig := method.Type().(*types.Signature)
+			scope := f.NewFuncScope()
+			tm := TemplateMethod{
+				Name:     method.Name(),
+				Variadic: sig.Variadic(),
+			}
+			if ms := iface.Syntax.method(method.Name()); ms != nil {
+				tm.Doc = strings.TrimSuffix(ms.Doc.Text(), "\n")
+			}
+			for j := 0; j < sig.Params().Len(); j++ {
+				param := sig.Params().At(j)
+				tm.Params = append(tm.Params, TemplateVar{
+					Name:      paramName(param, scope),
+					Type:      typeString(param.Type()),
+					IsContext: isContext(param.Type()),
+				})
+			}
+			for j := 0; j < sig.Results().Len(); j++ {
+				result := sig.Results().At(j)
+				name := result.Name()
+				if emptyOrUnderscore(name) {
+					name = fmt.Sprintf("res%v", j)
+				}
+				tm.Results = append(tm.Results, TemplateVar{
+					Name:    scope.Declare(name),
+					Type:    typeString(result.Type()),
+					IsError: isError(result.Type()),
+				})
+			}
+			ti.Methods = append(ti.Methods, tm)
+		}
+		data.Interfaces = append(data.Interfaces, ti)
+	}
+	err = tmpl.Execute(f, data)
+	if err != nil {
+		return fmt.Errorf("template execute: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+// Package gmgapi allows to generate code like gmg command does, but without process spawn and disk writes.
+package gmgapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/spf13/afero"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/skipor/gmg/internal/app"
+)
+
+// Config mirrors gmg command flags. See `gmg --help` for details.
+type Config struct {
+	// Dir is working directory, that relative paths are resolved against. Current working directory by default.
+	Dir string
+	// Env is environment of Go tooling, that is invoked under hood. os.Environ() by default.
+	// GOFILE, GOLINE and GOPACKAGE may be set here to emulate 'go generate' call.
+	Env []string
+	// Fs is file system, that sources, templates and existing destination files are read from. OS file system by default.
+	// Go files of source and destination packages are passed to Go tooling as overlay, so they may be not on disk,
+	// for example unsaved editor buffers.
+	// Generated files are never written to Fs.
+	Fs afero.Fs
+
+	// Src is source Go package to search for interfaces. "." by default.
+	Src string
+	// Dst is destination directory or file relative path or pattern. "./mocks" by default.
+	Dst string
+	// Pkg is package name in generated files. Deduced from Dst by default.
+	Pkg string
+
+	// Interfaces are names of interfaces to generate code for.
+	Interfaces []string
+	// All selects all interfaces in package.
+	All bool
+	// AllFile selects all interfaces in GOFILE. Can be used only when 'go generate' call emulated via Env.
+	AllFile bool
+	// Annotated selects interfaces in package, which doc comment contains '//gmg:mock' directive.
+	Annotated bool
+	// PackageKind is kind of package, which interfaces are selected by All or Annotated:
+	// "primary", "test", "black-box-test" or "any". "primary" by default.
+	PackageKind string
+	// Match is Go regexp, that names of interfaces selected by All, AllFile or Annotated should match.
+	Match string
+	// Exclude is Go regexp, that names of interfaces selected by All, AllFile or Annotated should not match.
+	Exclude string
+
+	// Backend is kind of generated code: "mock", "logging" or "faulty". "mock" by default.
+	Backend string
+	// Template is path to template, that is used instead of Backend.
+	Template string
+	// Mode is the way source package is loaded: "packages" or "source". "packages" by default.
+	Mode string
+	// SharedRuntime makes mocks use github.com/skipor/gmg/pkg/gmgrt call wrappers instead of generated ones.
+	SharedRuntime bool
+	// Debug enables debug Diagnostics.
+	Debug bool
+}
+
+// Result is generation result.
+type Result struct {
+	Files []File
+	// Diagnostics are warnings and errors, that were logged during generation.
+	// With Config.Debug all logged messages are included.
+	Diagnostics []Diagnostic
+}
+
+// File is generated file.
+type File struct {
+	// Path is file path relative to Config.Dir, or absolute path.
+	Path    string
+	Content []byte
+}
+
+// Diagnostic is message logged during generation.
+type Diagnostic struct {
+	Level   string
+	Message string
+}
+
+func (d Diagnostic) String() string { return d.Level + ": " + d.Message }
+
+// Generate generates files like gmg command, but returns them instead of writing to disk.
+// Diagnostics are returned even when generation fails.
+func Generate(ctx context.Context, c Config) (Result, error) {
+	level := zapcore.WarnLevel
+	if c.Debug {
+		level = zapcore.DebugLevel
+	}
+	core := &diagnosticsCore{LevelEnabler: level}
+	env, err := environment(c)
+	if err != nil {
+		return Result{}, err
+	}
+	env.LogCore = core
+
+	files, err := app.Generate(ctx, env)
+	res := Result{Diagnostics: core.diagnostics()}
+	if err != nil {
+		return res, err
+	}
+	for _, f := range files {
+		content, err := f.Content()
+		if err != nil {
+			return res, fmt.Errorf("file %s render: %w", f.Path(), err)
+		}
+		res.Files = append(res.Files, File{
+			Path:    f.Path(),
+			Content: content,
+		})
+	}
+	return res, nil
+}
+
+// diagnosticsCore is zapcore.Core, that collects logged messages as diagnostics. Fields are ignored.
+type diagnosticsCore struct {
+	zapcore.LevelEnabler
+	mu      sync.Mutex
+	entries []Diagnostic
+}
+
+var _ zapcore.Core = (*diagnosticsCore)(nil)
+
+func (c *diagnosticsCore) With([]zapcore.Field) zapcore.Core { return c }
+
+func (c *diagnosticsCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c *diagnosticsCore) Write(entry zapcore.Entry, _ []zapcore.Field) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, Diagnostic{
+		Level:   entry.Level.String(),
+		Message: entry.Message,
+	})
+	return nil
+}
+
+func (c *diagnosticsCore) Sync() error { return nil }
+
+func (c *diagnosticsCore) diagnostics() []Diagnostic {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Diagnostic(nil), c.entries...)
+}
+
+func environment(c Config) (*app.Environment, error) {
+	dir := c.Dir
+	if dir == "" {
+		var err error
+		dir, err = os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("get workdir: %w", err)
+		}
+	}
+	env := c.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	fs := c.Fs
+	if fs == nil {
+		fs = afero.NewOsFs()
+	}
+	return &app.Environment{
+		Args:   args(c),
+		Stdout: io.Discard,
+		Stderr: io.Discard,
+		Dir:    dir,
+		Env:    env,
+		Fs:     fs,
+	}, nil
+}
+
+func args(c Config) []string {
+	var args []string
+	flag := func(name, value string) {
+		if value != "" {
+			args = append(args, "--"+name, value)
+		}
+	}
+	boolFlag := func(name string, value bool) {
+		if value {
+			args = append(args, "--"+name)
+		}
+	}
+	flag("src", c.Src)
+	flag("dst", c.Dst)
+	flag("pkg", c.Pkg)
+	flag("kind", c.Backend)
+	flag("template", c.Template)
+	flag("mode", c.Mode)
+	flag("package-kind", c.PackageKind)
+	flag("match", c.Match)
+	flag("exclude", c.Exclude)
+	boolFlag("all", c.All)
+	boolFlag("all-file", c.AllFile)
+	boolFlag("annotated", c.Annotated)
+	boolFlag("shared-runtime", c.SharedRuntime)
+	boolFlag("debug", c.Debug)
+	args = append(args, "--")
+	return append(args, c.Interfaces...)
+}
@@ -0,0 +1,113 @@
+// Package gmgrt is runtime of mocks generated by gmg with --shared-runtime flag.
+// Generated code declares call wrappers as aliases of generic types from this package,
+// instead of generating dedicated type with methods for every mocked method.
+// That makes mocks much smaller, and faster to compile.
+//
+// CallN is wrapper of call of method with N results.
+// Type parameter F is method func type, and D is the same func type without results.
+// R0, R1, ... are method result types.
+package gmgrt
+
+import (
+	"github.com/golang/mock/gomock"
+)
+
+// Call0 is type safe wrapper of *gomock.Call of method without results.
+type Call0[F any] struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c Call0[F]) DoAndReturn(f F) Call0[F] {
+	c.Call.DoAndReturn(f)
+	return c
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c Call0[F]) Do(f F) Call0[F] {
+	c.Call.Do(f)
+	return c
+}
+
+// Call1 is type safe wrapper of *gomock.Call of method with one result.
+type Call1[F, D, R0 any] struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c Call1[F, D, R0]) DoAndReturn(f F) Call1[F, D, R0] {
+	c.Call.DoAndReturn(f)
+	return c
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c Call1[F, D, R0]) Do(f D) Call1[F, D, R0] {
+	c.Call.Do(f)
+	return c
+}
+
+// Return is type safe wrapper of *gomock.Call Return.
+func (c Call1[F, D, R0]) Return(r0 R0) Call1[F, D, R0] {
+	c.Call.Return(r0)
+	return c
+}
+
+// Call2 is type safe wrapper of *gomock.Call of method with two results.
+type Call2[F, D, R0, R1 any] struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c Call2[F, D, R0, R1]) DoAndReturn(f F) Call2[F, D, R0, R1] {
+	c.Call.DoAndReturn(f)
+	return c
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c Call2[F, D, R0, R1]) Do(f D) Call2[F, D, R0, R1] {
+	c.Call.Do(f)
+	return c
+}
+
+// Return is type safe wrapper of *gomock.Call Return.
+func (c Call2[F, D, R0, R1]) Return(r0 R0, r1 R1) Call2[F, D, R0, R1] {
+	c.Call.Return(r0, r1)
+	return c
+}
+
+// Call3 is type safe wrapper of *gomock.Call of method with three results.
+type Call3[F, D, R0, R1, R2 any] struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c Call3[F, D, R0, R1, R2]) DoAndReturn(f F) Call3[F, D, R0, R1, R2] {
+	c.Call.DoAndReturn(f)
+	return c
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c Call3[F, D, R0, R1, R2]) Do(f D) Call3[F, D, R0, R1, R2] {
+	c.Call.Do(f)
+	return c
+}
+
+// Return is type safe wrapper of *gomock.Call Return.
+func (c Call3[F, D, R0, R1, R2]) Return(r0 R0, r1 R1, r2 R2) Call3[F, D, R0, R1, R2] {
+	c.Call.Return(r0, r1, r2)
+	return c
+}
+
+// Call4 is type safe wrapper of *gomock.Call of method with four results.
+// Generated mocks of methods with more results have dedicated call wrapper types.
+type Call4[F, D, R0, R1, R2, R3 any] struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c Call4[F, D, R0, R1, R2, R3]) DoAndReturn(f F) Call4[F, D, R0, R1, R2, R3] {
+	c.Call.DoAndReturn(f)
+	return c
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c Call4[F, D, R0, R1, R2, R3]) Do(f D) Call4[F, D, R0, R1, R2, R3] {
+	c.Call.Do(f)
+	return c
+}
+
+// Return is type safe wrapper of *gomock.Call Return.
+func (c Call4[F, D, R0, R1, R2, R3]) Return(r0 R0, r1 R1, r2 R2, r3 R3) Call4[F, D, R0, R1, R2, R3] {
+	c.Call.Return(r0, r1, r2, r3)
+	return c
+}
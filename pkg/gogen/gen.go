@@ -10,9 +10,10 @@ import (
 	"go/token"
 	"go/types"
 	"path"
-	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/spf13/afero"
 )
@@ -35,8 +36,17 @@ func ModulePath(path string) Option {
 	}
 }
 
+// RenderWorkers sets max number of files rendered concurrently by Generator.Render.
+// By default, it is runtime.GOMAXPROCS(0).
+func RenderWorkers(n int) Option {
+	return func(opts *options) {
+		opts.renderWorkers = n
+	}
+}
+
 type options struct {
-	modPath string
+	modPath       string
+	renderWorkers int
 }
 
 type Generator struct {
@@ -70,14 +80,64 @@ func (g *Generator) NewFile(path string, importPath ImportPath) *File {
 }
 
 func (g *Generator) Files() []*File { return g.files }
+
+// Render renders not skipped files concurrently. See RenderFiles function for details.
+func (g *Generator) Render() error {
+	return RenderFiles(g.files, g.opts.renderWorkers)
+}
+
+// WriteFiles writes not skipped files all-or-nothing. See WriteFiles function for details.
 func (g *Generator) WriteFiles(fs afero.Fs) error {
-	for _, f := range g.files {
-		if f.skipped {
+	_, err := WriteFiles(fs, g.files)
+	return err
+}
+
+// NewRenderedFile returns file, which content is already rendered. For example, restored from cache.
+func NewRenderedFile(path string, content []byte) *File {
+	return &File{path: path, rendered: &renderResult{content: content}}
+}
+
+// RenderFiles renders not skipped files concurrently, by at most workers goroutines.
+// If workers is not positive, runtime.GOMAXPROCS(0) is used.
+// Rendered content is remembered, so following File.Content calls return it without rendering.
+// Error of the first file in passed order, that failed to render, is returned,
+// so reported error doesn't depend on goroutines scheduling.
+func RenderFiles(files []*File, workers int) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	var toRender []*File
+	seen := map[*File]bool{}
+	for _, f := range files {
+		if f.Skipped() || f.rendered != nil || seen[f] {
 			continue
 		}
-		err := f.WriteFile(fs)
-		if err != nil {
-			return fmt.Errorf("file %s: %w", f.path, err)
+		seen[f] = true
+		toRender = append(toRender, f)
+	}
+	if workers > len(toRender) {
+		workers = len(toRender)
+	}
+	jobs := make(chan *File)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range jobs {
+				content, err := f.render()
+				f.rendered = &renderResult{content: content, err: err}
+			}
+		}()
+	}
+	for _, f := range toRender {
+		jobs <- f
+	}
+	close(jobs)
+	wg.Wait()
+	for _, f := range files {
+		if !f.Skipped() && f.rendered.err != nil {
+			return fmt.Errorf("file %s: render: %w", f.path, f.rendered.err)
 		}
 	}
 	return nil
@@ -93,6 +153,16 @@ type File struct {
 	fileScope *Scope
 	skipped   bool
 	buf       bytes.Buffer
+	// rendered is set for file created via NewRenderedFile, or rendered by RenderFiles.
+	// It is reset, when file is changed via its methods.
+	rendered *renderResult
+	// onRender are applied to formatted content in order.
+	onRender []func(content []byte) []byte
+}
+
+type renderResult struct {
+	content []byte
+	err     error
 }
 
 func (f *File) Path() string {
@@ -100,6 +170,13 @@ func (f *File) Path() string {
 }
 
 func (f *File) Content() ([]byte, error) {
+	if f.rendered != nil {
+		return f.rendered.content, f.rendered.err
+	}
+	return f.render()
+}
+
+func (f *File) render() ([]byte, error) {
 	// TODO(skipor): optional: `// Code generated by ` + f.g.name + `. DO NOT EDIT.`
 	file, err := parseFile(f.bufWithImports())
 	if err != nil {
@@ -110,8 +187,16 @@ func (f *File) Content() ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("reformat: %w", err)
 	}
+	for _, fn := range f.onRender {
+		content = fn(content)
+	}
 	return content, nil
+}
 
+// OnRender adds function, that transforms formatted content. For example, to put content hash into header.
+func (f *File) OnRender(fn func(content []byte) []byte) {
+	f.onRender = append(f.onRender, fn)
+	f.rendered = nil
 }
 
 func (f *File) bufWithImports() []byte {
@@ -218,27 +303,12 @@ func (f *File) WriteFile(FS afero.Fs) error {
 	if f.Skipped() {
 		return fmt.Errorf("file skipped")
 	}
-	content, err := f.Content()
-	if err != nil {
-		return fmt.Errorf("render: %w", err)
-	}
-
-	dir, _ := filepath.Split(f.path)
-	if !(dir == "" || dir == ".") {
-		err := FS.MkdirAll(dir, 0755)
-		if err != nil {
-			return fmt.Errorf("make dir all: %w", err)
-		}
-	}
-
-	err = afero.WriteFile(FS, f.path, content, 0644)
-	if err != nil {
-		return fmt.Errorf("write: %w", err)
-	}
-	return nil
+	_, err := WriteFiles(FS, []*File{f})
+	return err
 }
 
 func (f *File) P(args ...interface{}) *File {
+	f.rendered = nil
 	for _, arg := range args {
 		switch arg := arg.(type) {
 		case Ident:
@@ -261,27 +331,41 @@ func (f *File) L(args ...interface{}) *File {
 }
 
 func (f *File) Write(p []byte) (int, error) {
+	f.rendered = nil
 	return f.buf.Write(p)
 }
 
+// Buffer returns file content buffer. It should not be changed after file is rendered by RenderFiles.
 func (f *File) Buffer() *bytes.Buffer {
 	return &f.buf
 }
 
 func (f *File) QualifiedIdent(i Ident) string {
-	return f.QualifiedImportPath(i.ImportPath) + "." + i.Name
+	qualifier := f.QualifiedImportPath(i.ImportPath)
+	if qualifier == "" {
+		return i.Name
+	}
+	return qualifier + "." + i.Name
 }
 
+// QualifiedImportPath returns name of imported package, or empty string if path is the file package import path.
 func (f *File) QualifiedImportPath(path ImportPath) string {
+	if path == f.importPath {
+		return ""
+	}
 	f.Import(path)
 	return f.importToName[path]
 }
 
 func (f *File) Import(p ImportPath) {
+	if p == f.importPath {
+		return
+	}
 	_, ok := f.importToName[p]
 	if ok {
 		return
 	}
+	f.rendered = nil
 	f.importToName[p] = f.uniqueImportName(p)
 }
 
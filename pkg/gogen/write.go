@@ -0,0 +1,176 @@
+package gogen
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// WriteFiles writes files all-or-nothing.
+// All files are rendered first, then every file is written to temp file in the same dir and renamed into place.
+// If any step fails, already replaced files are restored, and created files and dirs are removed.
+// Files, which content is equal to existing, are not touched, to not bump their modification time.
+// Replaced files keep their permissions, and new files are created with 0644 ones.
+// Returns written files.
+func WriteFiles(FS afero.Fs, files []*File) ([]*File, error) {
+	w := &filesWriter{fs: FS}
+	written, err := w.write(files)
+	if err != nil {
+		if rollbackErr := w.rollback(); rollbackErr != nil {
+			err = fmt.Errorf("%w; rollback failed: %v", err, rollbackErr)
+		}
+		return nil, err
+	}
+	return written, nil
+}
+
+type filesWriter struct {
+	fs afero.Fs
+	// replaced are files that were replaced or created, in order of replacement.
+	replaced []replacedFile
+	// createdDirs are top-level dirs, that were created.
+	createdDirs []string
+}
+
+type replacedFile struct {
+	path string
+	// existed is set, when file existed before replace.
+	existed bool
+	// previous is previous content of file, when it existed.
+	previous []byte
+	// mode is permissions of file.
+	mode os.FileMode
+}
+
+func (w *filesWriter) write(files []*File) ([]*File, error) {
+	type change struct {
+		file    *File
+		content []byte
+		replacedFile
+	}
+	var changes []change
+	for _, f := range files {
+		if f.Skipped() {
+			continue
+		}
+		content, err := f.Content()
+		if err != nil {
+			return nil, fmt.Errorf("file %s: render: %w", f.path, err)
+		}
+		r := replacedFile{path: f.path, mode: 0644}
+		info, err := w.fs.Stat(f.path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("file %s: stat: %w", f.path, err)
+		}
+		if err == nil {
+			r.existed, r.mode = true, info.Mode().Perm()
+			r.previous, err = afero.ReadFile(w.fs, f.path)
+			if err != nil {
+				return nil, fmt.Errorf("file %s: read: %w", f.path, err)
+			}
+			if bytes.Equal(r.previous, content) {
+				continue
+			}
+		}
+		changes = append(changes, change{file: f, content: content, replacedFile: r})
+	}
+	var written []*File
+	for _, c := range changes {
+		err := w.mkdirAll(filepath.Dir(c.file.path))
+		if err != nil {
+			return nil, fmt.Errorf("file %s: make dir all: %w", c.file.path, err)
+		}
+		err = replaceFile(w.fs, c.file.path, c.content, c.mode)
+		if err != nil {
+			return nil, fmt.Errorf("file %s: %w", c.file.path, err)
+		}
+		w.replaced = append(w.replaced, c.replacedFile)
+		written = append(written, c.file)
+	}
+	return written, nil
+}
+
+// mkdirAll creates dir with parents, remembering the topmost created dir.
+func (w *filesWriter) mkdirAll(dir string) error {
+	topmost := ""
+	for d := dir; ; d = filepath.Dir(d) {
+		exists, err := afero.DirExists(w.fs, d)
+		if err != nil {
+			return err
+		}
+		if exists {
+			break
+		}
+		topmost = d
+		if parent := filepath.Dir(d); parent == d {
+			break
+		}
+	}
+	if topmost == "" {
+		return nil
+	}
+	err := w.fs.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+	w.createdDirs = append(w.createdDirs, topmost)
+	return nil
+}
+
+func (w *filesWriter) rollback() error {
+	var errs []error
+	for i := len(w.replaced) - 1; i >= 0; i-- {
+		r := w.replaced[i]
+		var err error
+		if !r.existed {
+			err = w.fs.Remove(r.path)
+		} else {
+			err = replaceFile(w.fs, r.path, r.previous, r.mode)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("file %s restore: %w", r.path, err))
+		}
+	}
+	for i := len(w.createdDirs) - 1; i >= 0; i-- {
+		err := w.fs.RemoveAll(w.createdDirs[i])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("dir %s remove: %w", w.createdDirs[i], err))
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// replaceFile writes content to temp file with mode in the same dir, and renames it to path.
+// That is, file on path is never partially written.
+func replaceFile(FS afero.Fs, path string, content []byte, mode os.FileMode) error {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := afero.TempFile(FS, dir, "."+base+".tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = FS.Chmod(tmpPath, mode)
+	}
+	if err == nil {
+		err = FS.Rename(tmpPath, path)
+	}
+	if err != nil {
+		_ = FS.Remove(tmpPath)
+		return fmt.Errorf("write: %w", err)
+	}
+	return nil
+}
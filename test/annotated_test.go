@@ -0,0 +1,152 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotated(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"store.go": /* language=go */ `
+			package pkg
+			// Store stores things.
+			//gmg:mock name=FakeStore
+			type Store interface { Get(key string) (string, error) }
+
+			type (
+				//gmg:mock dst=./clockmocks
+				Clock interface { Now() int64 }
+				Helper interface { Help() }
+			)
+			`,
+			"other.go": /* language=go */ `
+			package pkg
+			//gmg:mock
+			type Other interface { O() }
+			`,
+			"store_test.go": /* language=go */ `
+			package pkg
+			//gmg:mock
+			type Test interface { T() }
+			`,
+		},
+	})
+	tr.WriteToDisk()
+	tr.Gmg(t, "--annotated").
+		Succeed().
+		Files("mocks/store.go", "mocks/other.go", "clockmocks/clock.go").
+		StderrContains("written: mocks/store.go").
+		Golden()
+	t.Run("regen", func(t *testing.T) {
+		tr.Gmg(t, "regen").Succeed().Files().StderrContains("unchanged: store.go")
+	})
+	t.Run("exclude", func(t *testing.T) {
+		tr.Gmg(t, "--annotated", "--dst", "./excluded", "--exclude", "^(Other|Clock)$").Succeed().Files("excluded/store.go")
+	})
+	t.Run("with names", func(t *testing.T) {
+		tr.Gmg(t, "--annotated", "Helper").
+			Fail().
+			StderrContains("can't use --annotated together with --all, --all-file or interface names")
+	})
+}
+
+func TestAnnotated_MockNameUsedForAnySelection(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"store.go": /* language=go */ `
+			package pkg
+			//gmg:mock name=FakeStore
+			type Store interface { Get(key string) (string, error) }
+			`,
+		},
+	})
+	for _, args := range [][]string{{"Store"}, {"--all"}, {"--all", "--match", "Store"}} {
+		res := tr.Gmg(t, append([]string{"--dst", "-"}, args...)...).Succeed()
+		require.Contains(t, res.Stdout, "type FakeStore struct")
+	}
+}
+
+func TestAnnotated_SubPackage(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"sub/store.go": /* language=go */ `
+			package sub
+			//gmg:mock dst=./fakes/store.go
+			type Store interface { Get(key string) (string, error) }
+			//gmg:mock
+			type Clock interface { Now() int64 }
+			`,
+		},
+	})
+	// Annotation dst is relative to interface package dir, but --dst is relative to working dir.
+	tr.Gmg(t, "--src", "./sub", "--annotated").
+		Succeed().
+		Files("sub/fakes/store.go", "mocks/clock.go")
+}
+
+func TestAnnotated_Recursive(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"a/a.go": /* language=go */ `
+			package a
+			//gmg:mock
+			type A interface { A() }
+			type NotAnnotated interface { N() }
+			`,
+			"b/b.go": /* language=go */ `
+			package b
+			//gmg:mock dst=./fakes/{}_mock.go
+			type B interface { B() }
+			`,
+		},
+	})
+	tr.Gmg(t, "--src", "./...", "--annotated").Succeed().Files("a/mocks/a.go", "b/fakes/b_mock.go")
+}
+
+func TestAnnotated_InvalidOption(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"store.go": /* language=go */ `
+			package pkg
+			//gmg:mock pkg=mocks
+			type Store interface { Get(key string) (string, error) }
+			`,
+		},
+	})
+	tr.Gmg(t, "--annotated").
+		Fail().
+		StderrContains("interface Store //gmg:mock comment: unknown option 'pkg', expected one of: dst, name")
+}
+
+func TestAnnotated_MockNameKeptOnRegen(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"store.go": /* language=go */ `
+			package pkg
+			//gmg:mock name=FakeStore
+			type Store interface { Get(key string) (string, error) }
+			`,
+		},
+	})
+	tr.WriteToDisk()
+	tr.Gmg(t, "--annotated").Succeed().Files("mocks/store.go")
+
+	// Recorded command selects interface by name, but annotation name option is still applied.
+	err := os.WriteFile(filepath.Join(tr.exported.Config.Dir, "store.go"), []byte(
+		"package pkg\n//gmg:mock name=FakeStore\ntype Store interface { Get(key string) (string, error); Put(key, value string) }\n"), 0644)
+	require.NoError(t, err)
+	tr.Gmg(t, "regen").Succeed().Files("mocks/store.go")
+	content, err := os.ReadFile(filepath.Join(tr.exported.Config.Dir, "mocks", "store.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(content), "func (m_ *FakeStore) Put(")
+}
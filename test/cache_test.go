@@ -0,0 +1,144 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			import "repo/pkg/types"
+			type Foo interface { Foo() types.T }
+			`,
+			"types/types.go": /* language=go */ `
+			package types
+			type T int
+			`,
+		},
+	})
+	tr.WriteToDisk()
+	tr.UseCache()
+	write := func(path string, content string) {
+		err := os.WriteFile(filepath.Join(tr.exported.Config.Dir, path), []byte(content), 0644)
+		require.NoError(t, err)
+	}
+	remove := func(path string) {
+		err := os.Remove(filepath.Join(tr.exported.Config.Dir, path))
+		require.NoError(t, err)
+	}
+
+	tr.Gmg(t, "Foo").Succeed().Files("mocks/foo.go").StderrContains("Generation cache miss")
+	tr.Gmg(t, "Foo").Succeed().Files().StderrContains("Generation cache hit", "unchanged: mocks/foo.go")
+
+	remove("mocks/foo.go")
+	res := tr.Gmg(t, "Foo").Succeed().DeletedFiles().Files("mocks/foo.go").StderrContains("Generation cache hit")
+	require.NotContains(t, res.Stderr, "Loading packages")
+
+	t.Run("imported package change", func(t *testing.T) {
+		write("types/types.go", "package types\ntype T string\n")
+		tr.Gmg(t, "Foo").Succeed().Files().StderrContains("Generation cache miss", "unchanged: mocks/foo.go")
+	})
+	t.Run("source change", func(t *testing.T) {
+		write("file.go", "package pkg\nimport \"repo/pkg/types\"\ntype Foo interface { Foo() types.T; Bar() }\n")
+		tr.Gmg(t, "Foo").Succeed().Files("mocks/foo.go").StderrContains("Generation cache miss")
+	})
+	t.Run("no cache", func(t *testing.T) {
+		remove("mocks/foo.go")
+		res := tr.Gmg(t, "--no-cache", "Foo").Succeed().DeletedFiles().Files("mocks/foo.go")
+		require.NotContains(t, res.Stderr, "Generation cache")
+	})
+}
+
+func TestCache_Trim(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			type Bar interface { Bar() }
+			`,
+		},
+	})
+	tr.WriteToDisk()
+	tr.UseCache()
+	tr.Gmg(t, "Foo").Succeed().StderrContains("Generation cache miss")
+	entries, err := filepath.Glob(filepath.Join(tr.cacheDir, "??", "*"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	old := time.Now().Add(-10 * 24 * time.Hour)
+	require.NoError(t, os.Chtimes(entries[0], old, old))
+	require.NoError(t, os.WriteFile(filepath.Join(tr.cacheDir, "trim.txt"), []byte("0"), 0644))
+	tr.Gmg(t, "Bar").Succeed().StderrContains("Generation cache trimmed: 1 of 2 entries deleted")
+	_, err = os.Stat(entries[0])
+	require.True(t, os.IsNotExist(err), "old entry should be deleted")
+
+	// Trim is done at most once a day.
+	res := tr.Gmg(t, "Foo").Succeed()
+	require.NotContains(t, res.Stderr, "Generation cache trimmed")
+}
+
+func TestCache_LocalDependencies(t *testing.T) {
+	t.Run("replace", func(t *testing.T) {
+		tr := newTester(t, M{
+			Name: "repo/pkg",
+			Files: map[string]interface{}{
+				"file.go": /* language=go */ `
+				package pkg
+				import "example.com/dep"
+				type Foo interface { Foo() dep.T }
+				`,
+			},
+		}, M{
+			Name: "example.com/dep",
+			Files: map[string]interface{}{
+				"dep.go": /* language=go */ `
+				package dep
+				type T int
+				`,
+			},
+		})
+		dir := tr.exported.Config.Dir
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "localdep"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "localdep", "go.mod"), []byte("module example.com/dep\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "localdep", "dep.go"), []byte("package dep\ntype T int\n"), 0644))
+		goMod, err := os.OpenFile(filepath.Join(dir, "go.mod"), os.O_APPEND|os.O_WRONLY, 0)
+		require.NoError(t, err)
+		_, err = goMod.WriteString("replace example.com/dep => ./localdep\n")
+		require.NoError(t, err)
+		require.NoError(t, goMod.Close())
+
+		tr.UseCache()
+		tr.Gmg(t, "Foo").
+			Succeed().
+			Files("mocks/foo.go").
+			StderrContains("Generation cache is not used: go.mod replaces example.com/dep by local dir")
+	})
+	t.Run("go.work", func(t *testing.T) {
+		tr := newTester(t, M{
+			Name: "repo/pkg",
+			Files: map[string]interface{}{
+				"file.go": /* language=go */ `
+				package pkg
+				type Foo interface { Foo() }
+				`,
+				"go.work": "go 1.22\nuse .\n",
+			},
+		})
+		tr.UseCache()
+		// Workspace mode doesn't allow -mod=mod, that may be set in environment.
+		tr.GmgEnv(t, []string{"GOFLAGS="}, "Foo").
+			Succeed().
+			Files("mocks/foo.go").
+			StderrContains("Generation cache is not used: go.work workspace is used")
+	})
+}
@@ -0,0 +1,85 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck_NotGenerated(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Bar() }
+			`,
+		},
+	})
+	res := tr.Gmg(t, "--check", "Foo").
+		Fail().
+		Files().
+		StdoutContains(
+			"--- /dev/null\n+++ b/mocks/foo.go\n",
+			"+type MockFoo struct",
+		).
+		StderrContains("1 of 1 generated files are stale")
+	require.NotContains(t, res.Stderr, "+++ b/mocks/foo.go")
+	require.NotContains(t, res.Stdout, "generated files are stale")
+}
+
+func TestCheck_Stale(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Bar() }
+			`,
+			"mocks/foo.go": /* language=go */ `
+			package mocks_pkg
+			type MockFoo struct{}
+			`,
+		},
+	})
+	tr.Gmg(t, "--check", "Foo").
+		Fail().
+		Files().
+		StdoutContains(
+			"--- a/mocks/foo.go\n+++ b/mocks/foo.go\n",
+			"-type MockFoo struct{}",
+		)
+}
+
+func TestCheck_UpToDate_Recursive(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"a/a.go": /* language=go */ `
+			package a
+			type A interface { A() }
+			`,
+			"b/b.go": /* language=go */ `
+			package b
+			type B interface { B() }
+			`,
+		},
+	})
+	tr.WriteToDisk()
+	tr.Gmg(t, "--src", "./...", "--all").Succeed().Files("a/mocks/a.go", "b/mocks/b.go")
+	tr.Gmg(t, "--src", "./...", "--all", "--check").Succeed().Files()
+}
+
+func TestCheck_GoGenerate(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			//go:generate gmg --check
+			type Foo interface { Bar() }
+			`,
+		},
+	})
+	tr.GoGenerate(t).Fail().Files()
+}
@@ -0,0 +1,44 @@
+package test
+
+import (
+	"testing"
+)
+
+func TestForce_HandWritten(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+			"client.go": /* language=go */ `
+			package pkg
+			type Client struct{}
+			`,
+		},
+	})
+	tr.Gmg(t, "--dst", "./client.go", "Foo").
+		Fail().
+		Files().
+		StderrContains("file client.go exists and is not generated")
+}
+
+func TestForce_HandWritten_Force(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+			"client.go": /* language=go */ `
+			package pkg
+			type Client struct{}
+			`,
+		},
+	})
+	tr.Gmg(t, "--dst", "./client.go", "--force", "Foo").
+		Succeed().
+		Files("client.go")
+}
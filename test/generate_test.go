@@ -0,0 +1,117 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestModule() M {
+	return M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			//go:generate gmg
+			type Foo interface { Foo() }
+			//go:generate echo not gmg directive
+			type Bar interface { Bar() }
+			`,
+			"a/a.go": /* language=go */ `
+			package a
+			import "repo/pkg"
+			//go:generate gmg --dst ./${GOPACKAGE}mocks --src io Reader
+			type A interface { A() pkg.Foo }
+			`,
+			"a/a_test.go": /* language=go */ `
+			package a_test
+			//go:generate gmg --all-file --dst "./mocks_test.go"
+			type ATest interface { AT() }
+			`,
+			"b/b.go": /* language=go */ `
+			package b
+			//go:generate gmg --src repo/pkg/a --all --kind logging
+			type B interface { B() }
+			`,
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	tr := newTester(t, generateTestModule())
+	tr.Gmg(t, "generate", "./...").
+		Succeed().
+		Files("mocks/foo.go", "a/amocks/reader.go", "a/mocks_test.go", "b/mocks/a.go").
+		StderrContains(
+			"file.go:3: gmg\n",
+			"a/a.go:5: gmg --dst ./amocks --src io Reader\n",
+			"a/a_test.go:3: gmg --all-file --dst ./mocks_test.go\n",
+			"b/b.go:3: gmg --src repo/pkg/a --all --kind logging\n",
+		).
+		Golden()
+}
+
+func TestGenerate_SameAsGoGenerate(t *testing.T) {
+	var goGenerate, gmgGenerate pathToFileContentMap
+	// Testers run in parallel, so group is waited for them.
+	t.Run("group", func(t *testing.T) {
+		t.Run("go generate", func(t *testing.T) {
+			res := newTester(t, generateTestModule()).GoGenerate(t).Succeed()
+			goGenerate = fsToMap(t, res.FS)
+		})
+		t.Run("gmg generate", func(t *testing.T) {
+			res := newTester(t, generateTestModule()).Gmg(t, "generate", "./...").Succeed()
+			gmgGenerate = fsToMap(t, res.FS)
+		})
+	})
+	require.NotEmpty(t, goGenerate)
+	diff := cmp.Diff(goGenerate, gmgGenerate)
+	if diff != "" {
+		t.Fatalf("'go generate' and 'gmg generate' results diff:\n%s", diff)
+	}
+}
+
+func TestGenerate_Fail(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			//go:generate gmg NotExist
+			type Foo interface { Foo() }
+			//go:generate gmg
+			type Bar interface { Bar() }
+			`,
+		},
+	})
+	tr.Gmg(t, "generate").
+		Fail().
+		Files("mocks/bar.go").
+		StderrContains("ERROR: file.go:3:", "1 of 2 directives failed")
+}
+
+func TestGenerate_WrittenPackageIsReloaded(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			//go:generate gmg --pkg fakes Foo
+			type Foo interface { Foo() }
+			//go:generate gmg Bar
+			type Bar interface { Bar() }
+			`,
+			"mocks/foo.go": /* language=go */ `
+			// Code generated by gmg. DO NOT EDIT.
+			package mocks
+			`,
+		},
+	})
+	// Bar mock package name is taken from Foo mock, that is written by previous directive.
+	tr.Gmg(t, "generate", "./...").
+		Succeed().
+		Files("mocks/foo.go", "mocks/bar.go").
+		StderrContains("is dropped, as its files or dependencies are written").
+		Golden()
+}
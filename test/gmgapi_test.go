@@ -0,0 +1,204 @@
+package test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skipor/gmg/pkg/gmgapi"
+)
+
+func TestGmgAPI_Generate(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Bar() string }
+			type Baz interface { Qux() }
+			`,
+		},
+	})
+	res, err := gmgapi.Generate(context.Background(), gmgapi.Config{
+		Dir: tr.exported.Config.Dir,
+		Env: tr.exported.Config.Env,
+		All: true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, res.Diagnostics)
+	require.Len(t, res.Files, 2)
+	assert.Equal(t, "mocks/baz.go", res.Files[0].Path)
+	assert.Contains(t, string(res.Files[0].Content), "type MockBaz struct")
+	assert.Equal(t, "mocks/foo.go", res.Files[1].Path)
+	assert.Contains(t, string(res.Files[1].Content), "type MockFoo struct")
+}
+
+func TestGmgAPI_Generate_Fail(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Bar() NotExisting }
+			`,
+		},
+	})
+	res, err := gmgapi.Generate(context.Background(), gmgapi.Config{
+		Dir:        tr.exported.Config.Dir,
+		Env:        tr.exported.Config.Env,
+		Backend:    "logging",
+		Interfaces: []string{"NotExisting"},
+	})
+	require.Error(t, err)
+	require.NotEmpty(t, res.Diagnostics)
+	assert.Equal(t, "warn", res.Diagnostics[0].Level)
+	assert.Contains(t, res.Diagnostics[0].Message, "undefined: NotExisting")
+}
+
+func TestGmgAPI_Generate_InMemorySource(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Bar() string }
+			`,
+		},
+	})
+	dir := tr.exported.Config.Dir
+	buffers := afero.NewMemMapFs()
+	err := afero.WriteFile(buffers, filepath.Join(dir, "file.go"), []byte(`
+package pkg
+type Foo interface { Unsaved() string }
+`), 0644)
+	require.NoError(t, err)
+
+	res, err := gmgapi.Generate(context.Background(), gmgapi.Config{
+		Dir:        dir,
+		Env:        tr.exported.Config.Env,
+		Fs:         afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(afero.NewOsFs()), buffers),
+		Interfaces: []string{"Foo"},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Files, 1)
+	assert.Contains(t, string(res.Files[0].Content), "func (m_ *MockFoo) Unsaved() string")
+	assert.NotContains(t, string(res.Files[0].Content), "Bar()")
+}
+
+func TestGmgAPI_Generate_SourceMode(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			import "example.com/not/existing"
+			type Foo interface { Bar() existing.T }
+			`,
+		},
+	})
+	res, err := gmgapi.Generate(context.Background(), gmgapi.Config{
+		Dir:        tr.exported.Config.Dir,
+		Env:        tr.exported.Config.Env,
+		Mode:       "source",
+		Interfaces: []string{"Foo"},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Files, 1)
+	assert.Contains(t, string(res.Files[0].Content), "func (m_ *MockFoo) Bar() existing.T")
+}
+
+func TestGmgAPI_Generate_SharedRuntime(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Bar() string }
+			`,
+		},
+	})
+	res, err := gmgapi.Generate(context.Background(), gmgapi.Config{
+		Dir:           tr.exported.Config.Dir,
+		Env:           tr.exported.Config.Env,
+		SharedRuntime: true,
+		Interfaces:    []string{"Foo"},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Files, 1)
+	assert.Contains(t, string(res.Files[0].Content), "gmgrt.Call1[")
+}
+
+func TestGmgAPI_Generate_MatchExclude(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type FooReader interface { Read() }
+			type FooWriter interface { Write() }
+			type Bar interface { Bar() }
+			`,
+		},
+	})
+	res, err := gmgapi.Generate(context.Background(), gmgapi.Config{
+		Dir:     tr.exported.Config.Dir,
+		Env:     tr.exported.Config.Env,
+		All:     true,
+		Match:   "^Foo",
+		Exclude: "Writer$",
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Files, 1)
+	assert.Equal(t, "mocks/foo_reader.go", res.Files[0].Path)
+}
+
+func TestGmgAPI_Generate_Annotated(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			//gmg:mock
+			type Foo interface { Foo() }
+			type Bar interface { Bar() }
+			`,
+		},
+	})
+	res, err := gmgapi.Generate(context.Background(), gmgapi.Config{
+		Dir:       tr.exported.Config.Dir,
+		Env:       tr.exported.Config.Env,
+		Annotated: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Files, 1)
+	assert.Equal(t, "mocks/foo.go", res.Files[0].Path)
+}
+
+func TestGmgAPI_Generate_PackageKind(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+			"file_test.go": /* language=go */ `
+			package pkg
+			type Bar interface { Bar() }
+			`,
+		},
+	})
+	res, err := gmgapi.Generate(context.Background(), gmgapi.Config{
+		Dir:         tr.exported.Config.Dir,
+		Env:         tr.exported.Config.Env,
+		All:         true,
+		PackageKind: "test",
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Files, 1)
+	assert.Equal(t, "mocks/bar.go", res.Files[0].Path)
+}
@@ -0,0 +1,58 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoVersion(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo(a int, b ...string) }
+			`,
+		},
+	})
+	tr.WriteToDisk()
+	// setGoVersion sets go directive version, or removes it, when version is empty.
+	setGoVersion := func(t *testing.T, version string) {
+		goModPath := filepath.Join(tr.exported.Config.Dir, "go.mod")
+		data, err := os.ReadFile(goModPath)
+		require.NoError(t, err)
+		data = regexp.MustCompile(`(?m)^go .*\n`).ReplaceAll(data, nil)
+		if version != "" {
+			data = append(data, "\ngo "+version+"\n"...)
+		}
+		require.NoError(t, os.WriteFile(goModPath, data, 0644))
+	}
+	setGoVersion(t, "1.17")
+	tr.Gmg(t, "Foo").Succeed().Files("mocks/foo.go").Golden()
+	tr.Gmg(t, "--shared-runtime", "--dst", "./shared/{}.go", "Foo").
+		Fail().
+		StderrContains("shared runtime requires Go 1.18, but module go version is 1.17")
+	tr.Gmg(t, "--kind", "logging", "--dst", "./logging/{}.go", "Foo").
+		Fail().
+		StderrContains("logging kind requires Go 1.21, but module go version is 1.17")
+
+	setGoVersion(t, "1.21")
+	res := tr.Gmg(t, "Foo").Succeed().Files("mocks/foo.go")
+	require.NotContains(t, res.Stderr, "is up to date")
+	content, err := os.ReadFile(filepath.Join(tr.exported.Config.Dir, "mocks/foo.go"))
+	require.NoError(t, err)
+	// Go 1.18 required by 'any' is implied by go directive.
+	require.NotContains(t, string(content), "// Requires:")
+	require.Contains(t, string(content), "func (r_ *MockFooMockRecorder) Foo(a any, b ...any)")
+
+	setGoVersion(t, "")
+	// Go command adds missing go directive, unless go.mod is readonly.
+	tr.GmgEnv(t, []string{"GOFLAGS=-mod=readonly"}, "--shared-runtime", "--dst", "./shared/{}.go", "Foo").Succeed().Files("shared/foo.go")
+	content, err = os.ReadFile(filepath.Join(tr.exported.Config.Dir, "shared/foo.go"))
+	require.NoError(t, err)
+	require.Contains(t, string(content), "// Requires: go1.18\n")
+}
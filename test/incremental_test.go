@@ -0,0 +1,80 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncremental(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			type Bar interface { Bar() }
+			`,
+		},
+	})
+	tr.WriteToDisk()
+	tr.Gmg(t, "--all").Succeed().Files("mocks/foo.go", "mocks/bar.go")
+	tr.Gmg(t, "--all").
+		Succeed().
+		Files().
+		StderrContains("File mocks/foo.go is up to date", "File mocks/bar.go is up to date", "unchanged: mocks/foo.go")
+
+	// Declaration order and comments are not part of method set.
+	err := os.WriteFile(filepath.Join(tr.exported.Config.Dir, "file.go"), []byte(
+		"package pkg\n// Bar doc.\ntype Bar interface { Bar() }\ntype Foo interface { Foo(); Baz(a int) }\n"), 0644)
+	require.NoError(t, err)
+	res := tr.Gmg(t, "--all").
+		Succeed().
+		Files("mocks/foo.go").
+		StderrContains("interface changed: repo/pkg.Foo\n", "File mocks/bar.go is up to date").
+		Golden()
+	require.NotContains(t, res.Stderr, "interface changed: repo/pkg.Bar")
+
+	res = tr.Gmg(t, "--all", "--no-incremental").Succeed().Files()
+	require.NotContains(t, res.Stderr, "is up to date")
+
+	old := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	err = os.Chtimes(filepath.Join(tr.exported.Config.Dir, "mocks", "bar.go"), old, old)
+	require.NoError(t, err)
+	tr.Gmg(t, "--all").
+		Succeed().
+		Files().
+		StderrContains("File mocks/bar.go is older than gmg executable", "File mocks/foo.go is up to date")
+}
+
+func TestIncremental_EditedBody(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+		},
+	})
+	tr.WriteToDisk()
+	tr.Gmg(t, "Foo").Succeed().Files("mocks/foo.go")
+	path := filepath.Join(tr.exported.Config.Dir, "mocks", "foo.go")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	err = os.WriteFile(path, append(content, "\nfunc broken() {\n"...), 0644)
+	require.NoError(t, err)
+
+	tr.Gmg(t, "--check", "Foo").
+		Fail().
+		StdoutContains("-func broken() {").
+		StderrContains("1 of 1 generated files are stale")
+	tr.Gmg(t, "Foo").
+		Succeed().
+		Files("mocks/foo.go").
+		StderrContains("File mocks/foo.go was edited after generation")
+	tr.Gmg(t, "--check", "Foo").Succeed()
+}
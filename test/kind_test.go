@@ -0,0 +1,84 @@
+package test
+
+import (
+	"testing"
+)
+
+func TestKind_Logging(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			import "context"
+			type Foo interface {
+				NoArgsAndResults()
+				Context(ctx context.Context, a int) (string, error)
+				NamedResults(a int) (b int, err error)
+				Variadic(f string, as ...int) error
+				// Login authenticates user.
+				//gmg:redact password
+				Login(user, password string) error
+				Token(
+					user string,
+					token string, //gmg:redact
+				) bool
+			}
+			`,
+		},
+	})
+	tr.
+		Gmg(t, "--kind", "logging", "Foo").Succeed().
+		Golden()
+}
+
+func TestKind_Logging_SamePackage(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Bar struct{}
+			type Foo interface { Get() (*Bar, error) }
+			`,
+		},
+	})
+	tr.
+		Gmg(t, "--kind", "logging", "--dst", "./{}_logging.go", "--pkg", "pkg", "Foo").Succeed().
+		Golden()
+}
+
+func TestKind_Unknown(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Bar() }
+			`,
+		},
+	})
+	tr.Gmg(t, "--kind", "unknown", "Foo").Fail()
+}
+
+func TestKind_Faulty(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			import "context"
+			type Foo interface {
+				NoArgsAndResults()
+				NoError(a int) string
+				Context(ctx context.Context, a int) (string, error)
+				NamedResults(a int) (b int, err error)
+				Variadic(f string, as ...int) error
+			}
+			`,
+		},
+	})
+	tr.
+		Gmg(t, "--kind", "faulty", "Foo").Succeed().
+		Golden()
+}
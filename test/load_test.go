@@ -0,0 +1,105 @@
+package test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"golang.org/x/tools/go/packages/packagestest"
+
+	"github.com/skipor/gmg/internal/app"
+)
+
+func TestLoad_TestPackagesOnDemand(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+			"file_test.go": /* language=go */ `
+			package pkg
+			//go:generate gmg
+			type Bar interface { Bar() }
+			`,
+		},
+	})
+	t.Run("primary", func(t *testing.T) {
+		tr.Gmg(t, "--dst", "-", "Foo").Succeed().StderrContains("Loading packages (tests: false)")
+	})
+	t.Run("test only", func(t *testing.T) {
+		tr.Gmg(t, "--dst", "-", "Bar").
+			Succeed().
+			StderrContains("Loading packages (tests: false)", "Interfaces Bar are not found in primary package", "Loading packages (tests: true)")
+	})
+	t.Run("go generate in test file", func(t *testing.T) {
+		tr.GmgEnv(t, []string{"GOFILE=file_test.go", "GOLINE=2", "GOPACKAGE=pkg"}, "--dst", "-").
+			Succeed().
+			StderrContains("Loading packages (tests: true)")
+	})
+}
+
+// BenchmarkLoad measures generation from synthetic module, which packages import each other in chain,
+// so load time is dominated by dependency graph size.
+func BenchmarkLoad(b *testing.B) {
+	const (
+		packagesNum   = 50
+		filesNum      = 5
+		interfacesNum = 10
+	)
+	files := map[string]interface{}{}
+	for p := 0; p < packagesNum; p++ {
+		for f := 0; f < filesNum; f++ {
+			src := &strings.Builder{}
+			fmt.Fprintf(src, "package pkg%d\n\nimport (\n\t\"context\"\n\t\"net/http\"\n", p)
+			if p != 0 {
+				fmt.Fprintf(src, "\n\t\"repo/pkg%d\"\n", p-1)
+			}
+			src.WriteString(")\n\n")
+			for i := 0; i < interfacesNum; i++ {
+				prev := "*http.Request"
+				if p != 0 {
+					prev = fmt.Sprintf("pkg%d.Iface%d_%d", p-1, f, i)
+				}
+				fmt.Fprintf(src, "type Iface%d_%d interface {\n\tDo(ctx context.Context, req %s) (*http.Response, error)\n}\n\n", f, i, prev)
+			}
+			files[fmt.Sprintf("pkg%d/file%d.go", p, f)] = src.String()
+		}
+		files[fmt.Sprintf("pkg%d/file_test.go", p)] = fmt.Sprintf("package pkg%d\n\nimport \"testing\"\n\ntype TestIface interface{ T() *testing.T }\n", p)
+		files[fmt.Sprintf("pkg%d/x_test.go", p)] = fmt.Sprintf("package pkg%d_test\n\nimport \"testing\"\n\ntype XTestIface interface{ T() *testing.T }\n", p)
+	}
+	e := packagestest.Export(b, x, []packagestest.Module{{Name: "repo", Files: files}})
+	b.Cleanup(e.Cleanup)
+	src := fmt.Sprintf("./pkg%d", packagesNum-1)
+	run := func(b *testing.B, env []string, args ...string) {
+		for i := 0; i < b.N; i++ {
+			stderr := &strings.Builder{}
+			exitCode := app.Main(&app.Environment{
+				Args:   append([]string{"--src", src, "--dst", "-"}, args...),
+				Stdout: io.Discard,
+				Stderr: stderr,
+				Dir:    e.Config.Dir,
+				Env:    append(append([]string{}, e.Config.Env...), env...),
+				Fs:     afero.NewOsFs(),
+			})
+			if exitCode != 0 {
+				b.Fatalf("gmg failed:\n%s", stderr)
+			}
+		}
+	}
+	b.Run("primary", func(b *testing.B) {
+		run(b, nil, "Iface0_0")
+	})
+	b.Run("test", func(b *testing.B) {
+		run(b, nil, "TestIface")
+	})
+	b.Run("go generate in black-box test", func(b *testing.B) {
+		run(b, []string{"GOFILE=x_test.go", "GOLINE=4", fmt.Sprintf("GOPACKAGE=pkg%d_test", packagesNum-1)})
+	})
+	b.Run("source mode", func(b *testing.B) {
+		run(b, nil, "--mode", "source", "Iface0_0")
+	})
+}
@@ -180,3 +180,138 @@ func TestMultiSelect_GoGenerate_All_File(t *testing.T) {
 	})
 	tr.GoGenerate(t).Succeed().Files("mocks/a_1.go", "mocks/a_2.go", "mocks/a_3.go")
 }
+
+func TestMultiSelect_Console_All_MatchExclude(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"service.go": /* language=go */ `
+			package pkg
+			type UserService interface { U() }
+			type OrderService interface { O() }
+			type UserRepo interface { R() }
+			type clock interface { Now() int64 }
+			`,
+			"a/a.go": /* language=go */ `
+			package a
+			type AService interface { A() }
+			type AHelper interface { H() }
+			`,
+		},
+	})
+	t.Run("match and exclude", func(t *testing.T) {
+		tr.Gmg(t, "--all", "--match", "Service$", "--exclude", "^Order").Succeed().Files("mocks/user_service.go")
+	})
+	t.Run("recursive", func(t *testing.T) {
+		tr.Gmg(t, "--src", "./...", "--all", "--dst", "./{}mocks", "--exclude", "Helper|^[a-z]").
+			Succeed().
+			Files("pkgmocks/user_service.go", "pkgmocks/order_service.go", "pkgmocks/user_repo.go", "a/amocks/a_service.go")
+	})
+	t.Run("nothing matched", func(t *testing.T) {
+		tr.Gmg(t, "--all", "--dst", "./nomocks", "--match", "NotExisting").Succeed().Files()
+	})
+	t.Run("without all", func(t *testing.T) {
+		tr.Gmg(t, "--match", "Service", "UserRepo").
+			Fail().
+			StderrContains("--match and --exclude can be used only with --all, --all-file or --annotated")
+	})
+	t.Run("invalid regexp", func(t *testing.T) {
+		tr.Gmg(t, "--all", "--exclude", "(").Fail().StderrContains("--exclude: error parsing regexp")
+	})
+}
+
+func TestMultiSelect_GoGenerate_AllFile_Match(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"service.go": /* language=go */ `
+			package pkg
+			//go:generate gmg --all-file --match Service
+			type UserService interface { U() }
+			type UserRepo interface { R() }
+			`,
+			"other.go": /* language=go */ `
+			package pkg
+			type OtherService interface { O() }
+			`,
+		},
+	})
+	tr.GoGenerate(t).
+		Succeed().
+		Files("mocks/user_service.go")
+}
+
+func TestMultiSelect_Console_All_PackageKind(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"primary.go": /* language=go */ `
+			package pkg
+			type Primary1 interface { P1()  }
+			type Primary2 interface { P2()  }
+			`,
+			"primary_test.go": /* language=go */ `
+			package pkg
+			type Test1 interface { T1() Primary1 }
+			type Test2 interface { T2()  }
+			`,
+			"black_box_test.go": /* language=go */ `
+			package pkg_test
+			type BlackBox1 interface { BB1()  }
+			type BlackBox2 interface { BB2()  }
+			`,
+		},
+	})
+	t.Run("test", func(t *testing.T) {
+		tr.Gmg(t, "--all", "--package-kind", "test", "--dst", "./mocks_test.go").
+			Succeed().
+			Files("mocks_test.go").
+			StderrContains("Loading packages (tests: true)").
+			Golden()
+	})
+	t.Run("black-box-test", func(t *testing.T) {
+		tr.Gmg(t, "--all", "--package-kind", "black-box-test", "--dst", "./black_box_mocks_test.go", "--pkg", "pkg_test").
+			Succeed().
+			Files("black_box_mocks_test.go").
+			Golden()
+	})
+	t.Run("any", func(t *testing.T) {
+		tr.Gmg(t, "--all", "--package-kind", "any", "--dst", "./all").
+			Succeed().
+			Files("all/primary_1.go", "all/primary_2.go", "all/test_1.go", "all/test_2.go", "all/black_box_1.go", "all/black_box_2.go")
+	})
+	t.Run("unknown", func(t *testing.T) {
+		tr.Gmg(t, "--all", "--package-kind", "tests").
+			Fail().
+			StderrContains("--package-kind: unknown kind 'tests', expected one of: primary, test, black-box-test, any")
+	})
+	t.Run("with names", func(t *testing.T) {
+		tr.Gmg(t, "--package-kind", "test", "Test1").
+			Fail().
+			StderrContains("--package-kind can be used only with --all or --annotated")
+	})
+}
+
+func TestMultiSelect_Console_All_PackageKind_AnyDuplicateName(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"primary.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo()  }
+			`,
+			"black_box_test.go": /* language=go */ `
+			package pkg_test
+			type Foo interface { Bar()  }
+			type Baz interface { Baz()  }
+			`,
+		},
+	})
+	tr.Gmg(t, "--all", "--package-kind", "any").
+		Fail().
+		Files().
+		StderrContains("interface Foo is declared in both repo/pkg and repo/pkg_test packages")
+	tr.Gmg(t, "--all", "--package-kind", "any", "--exclude", "^Foo$").
+		Succeed().
+		Files("mocks/baz.go")
+}
@@ -0,0 +1,103 @@
+package test
+
+import (
+	"testing"
+)
+
+// staleMock is file generated from interface, that is not exist anymore.
+const staleMock = /* language=go */ `
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: pkg.Removed
+
+package mocks_pkg
+
+type MockRemoved struct{}
+`
+
+func TestPrune_All(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+			"mocks/removed.go": staleMock,
+			"mocks/other_pkg.go": /* language=go */ `
+			// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+			// Source: io.Reader
+
+			package mocks_pkg
+			`,
+			"mocks/hand_written.go": /* language=go */ `
+			package mocks_pkg
+			`,
+		},
+	})
+	tr.WriteToDisk()
+	tr.Gmg(t, "--all", "--prune").
+		Succeed().
+		Files("mocks/foo.go").
+		DeletedFiles("mocks/removed.go").
+		StderrContains("deleted: mocks/removed.go")
+}
+
+func TestPrune_AllFile_OtherFileInterfacesKept(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+			"other.go": /* language=go */ `
+			package pkg
+			type Bar interface { Bar() }
+			`,
+			"mocks/bar.go": /* language=go */ `
+			// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+			// Source: pkg.Bar
+
+			package mocks_pkg
+			`,
+			"mocks/removed.go": staleMock,
+		},
+	})
+	tr.WriteToDisk()
+	// Not tr.GoGenerate, as 'go generate ./...' fails on deleted files of packages, that are processed later.
+	tr.GmgEnv(t, []string{"GOFILE=file.go", "GOLINE=1", "GOPACKAGE=pkg"}, "--all-file", "--prune").
+		Succeed().
+		Files("mocks/foo.go").
+		DeletedFiles("mocks/removed.go")
+}
+
+func TestPrune_Check(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+			"mocks/removed.go": staleMock,
+		},
+	})
+	tr.Gmg(t, "--all", "--prune", "--check").
+		Fail().
+		Files().
+		StdoutContains("--- a/mocks/removed.go\n+++ /dev/null\n").
+		StderrContains("1 files should be deleted")
+}
+
+func TestPrune_WithoutAll(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+		},
+	})
+	tr.Gmg(t, "--prune", "Foo").Fail().Files()
+}
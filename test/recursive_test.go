@@ -0,0 +1,76 @@
+package test
+
+import (
+	"testing"
+)
+
+func TestRecursive_All(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"root.go": /* language=go */ `
+			package pkg
+			type Root interface { R() }
+			`,
+			"a/a.go": /* language=go */ `
+			package a
+			import "repo/pkg/b"
+			type A interface { A() b.B }
+			`,
+			"a/a_test.go": /* language=go */ `
+			package a
+			type ATest interface { AT() }
+			`,
+			"b/b.go": /* language=go */ `
+			package b
+			type B interface { B() }
+			`,
+			"b/mocks/doc.go": /* language=go */ `
+			package mocks
+			type NotGenerated interface { N() }
+			`,
+			"c/c.go": /* language=go */ `
+			package c
+			type NoInterfaces struct{}
+			`,
+		},
+	})
+	tr.Gmg(t, "--src", "./...", "--all").
+		Succeed().
+		Files("mocks/root.go", "a/mocks/a.go", "b/mocks/b.go").
+		Golden()
+}
+
+func TestRecursive_All_SingleFile(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"a/a.go": /* language=go */ `
+			package a
+			type A1 interface { A1() }
+			type A2 interface { A2() }
+			`,
+			"b/b.go": /* language=go */ `
+			package b
+			type B interface { B() }
+			`,
+		},
+	})
+	tr.Gmg(t, "--src", "./...", "--all", "--dst", "./mocks_test.go", "--pkg", "{}").
+		Succeed().
+		Files("a/mocks_test.go", "b/mocks_test.go").
+		Golden()
+}
+
+func TestRecursive_WithoutAll(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"a/a.go": /* language=go */ `
+			package a
+			type A interface { A() }
+			`,
+		},
+	})
+	tr.Gmg(t, "--src", "./...", "A").Fail()
+}
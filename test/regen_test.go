@@ -0,0 +1,84 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegen(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+			"sub/mocks/old.go": /* language=go */ `
+			// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+			// Source: repo/pkg.Old
+
+			package mocks
+			`,
+		},
+	})
+	tr.WriteToDisk()
+	tr.Gmg(t, "Foo").Succeed().Files("mocks/foo.go")
+	tr.Gmg(t, "--src", "io", "--dst", "./sub/io_mocks", "--kind", "logging", "Reader").Succeed().Files("sub/io_mocks/reader.go")
+
+	err := os.WriteFile(filepath.Join(tr.exported.Config.Dir, "file.go"), []byte("package pkg\ntype Foo interface { Foo(); Bar() }\n"), 0644)
+	require.NoError(t, err)
+
+	tr.Gmg(t, "regen").
+		Succeed().
+		Files("mocks/foo.go").
+		StderrContains(
+			"regen: mocks/foo.go\n",
+			"written: foo.go\n",
+			"regen: sub/io_mocks/reader.go\n",
+			"unchanged: reader.go\n",
+			"skipped: sub/mocks/old.go",
+		).
+		Golden()
+}
+
+func TestRegen_Paths(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			type Bar interface { Bar() }
+			`,
+		},
+	})
+	tr.WriteToDisk()
+	tr.Gmg(t, "--dst", "./a", "Foo").Succeed().Files("a/foo.go")
+	tr.Gmg(t, "--dst", "./b", "Bar").Succeed().Files("b/bar.go")
+	err := os.WriteFile(filepath.Join(tr.exported.Config.Dir, "file.go"), []byte("package pkg\ntype Foo interface{}\ntype Bar interface{}\n"), 0644)
+	require.NoError(t, err)
+
+	tr.Gmg(t, "regen", "b").Succeed().Files("b/bar.go")
+}
+
+func TestRegen_Stdout(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+		},
+	})
+	res := tr.Gmg(t, "--dst", "-", "Foo").Succeed()
+	err := os.WriteFile(filepath.Join(tr.exported.Config.Dir, "foo_mock.go"), []byte(res.Stdout), 0644)
+	require.NoError(t, err)
+	tr.Gmg(t, "regen").
+		Fail().
+		Files().
+		StderrContains("foo_mock.go: recorded command printed file to stdout with '--dst -', so it can't be reproduced")
+}
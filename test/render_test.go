@@ -0,0 +1,47 @@
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender_ManyFiles(t *testing.T) {
+	const interfacesNum = 50
+	src := &strings.Builder{}
+	src.WriteString("package pkg\n")
+	for i := 0; i < interfacesNum; i++ {
+		fmt.Fprintf(src, "type Foo%02d interface { Foo(a int) error }\n", i)
+	}
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": src.String(),
+			// Every interface generated code is unparsable.
+			"broken.tmpl": "{{ range .Interfaces }}func {{ .Name }}( {{ end }}",
+		},
+	})
+	t.Run("order", func(t *testing.T) {
+		var expected []string
+		for i := 0; i < interfacesNum; i++ {
+			expected = append(expected, fmt.Sprintf("mocks/foo_%02d.go", i))
+		}
+		res := tr.Gmg(t, "--all").Succeed().Files(expected...)
+		written := regexp.MustCompile(`(?m)^written: (.*)$`).FindAllStringSubmatch(res.Stderr, -1)
+		var actual []string
+		for _, m := range written {
+			actual = append(actual, m[1])
+		}
+		require.Equal(t, expected, actual)
+	})
+	t.Run("error", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			tr.Gmg(t, "--all", "--template", "broken.tmpl", "--dst", "./broken/{}.go").
+				Fail().
+				StderrContains("file broken/foo_00.go: render: unparsable Go code")
+		}
+	})
+}
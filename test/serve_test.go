@@ -0,0 +1,105 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skipor/gmg/internal/app"
+)
+
+func TestServe(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+		},
+	})
+	tr.Serve(t)
+	tr.Gmg(t, "Foo").Succeed().Files("mocks/foo.go")
+	tr.Gmg(t, "Foo").
+		Succeed().
+		Files().
+		StderrContains("Package . is loaded from server cache", "unchanged: mocks/foo.go")
+
+	err := os.WriteFile(filepath.Join(tr.exported.Config.Dir, "file.go"), []byte("package pkg\ntype Foo interface { Foo(); Bar() }\n"), 0644)
+	require.NoError(t, err)
+	tr.Gmg(t, "Foo").
+		Succeed().
+		Files("mocks/foo.go").
+		StderrContains("server cache is invalidated").
+		Golden()
+}
+
+func TestServe_NotSocketIsNotTrusted(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+		},
+	})
+	socket := filepath.Join(tr.exported.Temp(), "gmg.sock")
+	err := os.WriteFile(socket, nil, 0600)
+	require.NoError(t, err)
+
+	err = app.Serve(context.Background(), &app.Environment{
+		Args:   []string{"--socket", socket},
+		Stderr: testWriter{t},
+		Dir:    tr.exported.Config.Dir,
+		Env:    tr.exported.Config.Env,
+		Fs:     afero.NewOsFs(),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not a socket")
+
+	tr.serverSocket = socket
+	tr.WriteToDisk()
+	tr.Gmg(t, "Foo").
+		Succeed().
+		Files("mocks/foo.go").
+		StderrContains("gmg server socket is not trusted", "is not a socket")
+}
+
+func TestServe_TransitiveImportChanged(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			import "repo/pkg/a"
+			type Foo interface { Foo() a.A }
+			`,
+			"a/a.go": /* language=go */ `
+			package a
+			import "repo/pkg/b"
+			type A = b.B
+			`,
+			"b/b.go": /* language=go */ `
+			package b
+			type B int
+			`,
+		},
+	})
+	tr.Serve(t)
+	tr.Gmg(t, "Foo").Succeed().Files("mocks/foo.go")
+	tr.Gmg(t, "Foo").
+		Succeed().
+		Files().
+		StderrContains("Package . is loaded from server cache")
+
+	err := os.WriteFile(filepath.Join(tr.exported.Config.Dir, "b", "b.go"), []byte("package b\ntype B string\n"), 0644)
+	require.NoError(t, err)
+	tr.Gmg(t, "Foo").
+		Succeed().
+		StderrContains("server cache is invalidated")
+}
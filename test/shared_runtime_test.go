@@ -0,0 +1,38 @@
+package test
+
+import (
+	"testing"
+)
+
+func TestSharedRuntime(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			import "io"
+			type Foo interface {
+				None(w io.Writer, _ int)
+				Many() (a, b, c, d, e int)
+				Four(args ...int) (int, string, bool, error)
+			}
+			`,
+		},
+	})
+	tr.Gmg(t, "--shared-runtime", "Foo").Succeed().Files("mocks/foo.go").Golden()
+}
+
+func TestSharedRuntime_NotMock(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+		},
+	})
+	tr.Gmg(t, "--shared-runtime", "--kind", "logging", "Foo").
+		Fail().
+		StderrContains("--shared-runtime can be used only with mock kind")
+}
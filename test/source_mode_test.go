@@ -0,0 +1,107 @@
+package test
+
+import (
+	"testing"
+)
+
+func TestSourceMode(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			import (
+				"context"
+				"io"
+
+				"repo/pkg/types"
+			)
+			type Foo interface {
+				io.Closer
+				Foo(ctx context.Context, ts ...types.T) (map[string][2]types.T, error)
+			}
+			`,
+			"file_test.go": /* language=go */ `
+			package pkg
+			type Bar interface { Bar(Foo) }
+			`,
+			"types/types.go": /* language=go */ `
+			package types
+			type T int
+			`,
+		},
+	})
+	tr.Gmg(t, "--mode", "source", "--dst", "./mocks_test.go", "Foo", "Bar").Succeed().Files("mocks_test.go").Golden()
+}
+
+func TestSourceMode_BrokenDependency(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			import (
+				"io"
+
+				"example.com/missing"
+				"repo/pkg/broken"
+			)
+			type Foo interface {
+				Foo(r io.Reader, m missing.Thing) (*missing.Other, []broken.T, error)
+				Bar(ch <-chan missing.Thing, f func(Undeclared) error)
+			}
+			`,
+			"broken/broken.go": /* language=go */ `
+			package broken
+			type T int
+			var _ T = "not int"
+			`,
+		},
+	})
+	tr.Gmg(t, "--mode", "source", "Foo").
+		Succeed().
+		Files("mocks/foo.go").
+		StderrContains("Packages loaded with").
+		Golden()
+}
+
+func TestSourceMode_RecursivePattern(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+		},
+	})
+	tr.Gmg(t, "--mode", "source", "--src", "./...", "--all").
+		Fail().
+		StderrContains("recursive --src pattern can't be loaded in source mode")
+}
+
+func TestSourceMode_GoFlagsTags(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "repo/pkg",
+		Files: map[string]interface{}{
+			"foo.go": /* language=go */ `
+			//go:build !foo
+			package pkg
+			type Foo interface { NotTagged() }
+			`,
+			"foo_tagged.go": /* language=go */ `
+			//go:build foo
+			package pkg
+			type Foo interface { Tagged() }
+			`,
+		},
+	})
+	for _, goflags := range []string{"-tags=foo", "--tags=foo", "-tags foo", "-mod=mod  --tags\tbar,foo"} {
+		tr.GmgEnv(t, []string{"GOFLAGS=" + goflags}, "--mode", "source", "--dst", "-", "Foo").
+			Succeed().
+			StdoutContains(") Tagged()")
+	}
+	tr.GmgEnv(t, []string{"GOFLAGS=-tags=bar"}, "--mode", "source", "--dst", "-", "Foo").
+		Succeed().
+		StdoutContains(") NotTagged()")
+}
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdout(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() Bar }
+			type Bar interface { Bar() }
+			`,
+		},
+	})
+	res := tr.Gmg(t, "--dst", "-", "--all").Succeed().Files()
+	require.Contains(t, res.Stdout, "package pkg\n")
+	assert.Contains(t, res.Stdout, "type MockFoo struct")
+	assert.Contains(t, res.Stdout, "type MockBar struct")
+	assert.Contains(t, res.Stdout, "func (m_ *MockFoo) Foo() Bar {")
+}
+
+func TestStdout_Recursive(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			`,
+		},
+	})
+	tr.Gmg(t, "--dst", "-", "--src", "./...", "--all").Fail().Files()
+}
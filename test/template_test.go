@@ -0,0 +1,81 @@
+package test
+
+import (
+	"testing"
+)
+
+const counterTemplate = `
+{{- range .Interfaces }}
+{{- $name := print "Counting" .Name }}
+// {{ $name }} counts {{ .Name }} method calls.
+{{- if .Doc }}
+//
+{{ comment .Doc }}
+{{- end }}
+type {{ $name }} struct {
+	Next {{ .Type }}
+	Calls {{ import "sync/atomic" }}.Int64
+}
+{{ range .Methods }}
+{{- if .Doc }}
+{{ comment .Doc }}
+{{- end }}
+func (c_ *{{ $name }}) {{ .Name }}({{ .ParamsDecl }}) {{ .ResultsDecl }} {
+	c_.Calls.Add(1)
+	{{ if .Results }}return {{ end }}c_.Next.{{ .Name }}({{ .CallArgs }})
+}
+{{ end }}
+{{- end }}
+`
+
+func TestTemplate(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			import "io"
+			// Foo is an example.
+			//
+			// Multiline doc.
+			type Foo interface {
+				// Bar does something.
+				Bar(w io.Writer, as ...int) (n int, err error)
+				Baz()
+			}
+			`,
+			"counter.tmpl": counterTemplate,
+		},
+	})
+	tr.
+		Gmg(t, "--template", "counter.tmpl", "Foo").Succeed().
+		Golden()
+}
+
+func TestTemplate_ExecuteFail(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Bar() }
+			`,
+			"invalid.tmpl": `{{ .NoSuchField }}`,
+		},
+	})
+	tr.Gmg(t, "--template", "invalid.tmpl", "Foo").Fail()
+}
+
+func TestTemplate_WithKind(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Bar() }
+			`,
+			"counter.tmpl": counterTemplate,
+		},
+	})
+	tr.Gmg(t, "--template", "counter.tmpl", "--kind", "logging", "Foo").Fail()
+}
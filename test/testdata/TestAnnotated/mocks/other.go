@@ -0,0 +1,62 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: repo/pkg.Other
+// Method set hash: Other=d6d5bc41eb760c8d
+// Command: gmg --src repo/pkg --dst ./other.go --pkg mocks_pkg Other
+// Body hash: 78a3c53df5c5464d
+
+package mocks_pkg
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// NewMockOther creates a new GoMock for repo/pkg.Other.
+func NewMockOther(ctrl *gomock.Controller) *MockOther {
+	return &MockOther{ctrl: ctrl}
+}
+
+// MockOther is a GoMock of repo/pkg.Other.
+type MockOther struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockOther) EXPECT() *MockOtherMockRecorder {
+	return (*MockOtherMockRecorder)(m_)
+}
+
+// O implements mocked interface.
+func (m_ *MockOther) O() {
+	m_.ctrl.T.Helper()
+	m_.ctrl.Call(m_, "O")
+	return
+}
+
+// MockOtherMockRecorder is the mock recorder for MockOther.
+type MockOtherMockRecorder MockOther
+
+// O()
+func (r_ *MockOtherMockRecorder) O() MockOtherOCall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "O", reflect.TypeOf((*MockOther)(nil).O))
+	return MockOtherOCall{call}
+}
+
+// MockOtherOCall is type safe wrapper of *gomock.Call.
+type MockOtherOCall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockOtherOCall) DoAndReturn(f func()) MockOtherOCall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockOtherOCall) Do(f func()) MockOtherOCall {
+	c_.Call.Do(f)
+	return c_
+}
+
+func (r_ *MockOtherMockRecorder) mock() *MockOther {
+	return (*MockOther)(r_)
+}
@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: io.Writer
+// Method set hash: Writer=f99de31becb39314
+// Command: gmg --src io --dst ./writer.go --pkg mocks_io Writer
+// Body hash: 9af82353fe13f177
 
 package mocks_io
 
@@ -35,7 +38,7 @@ func (m_ *MockWriter) Write(p []byte) (n int, err error) {
 type MockWriterMockRecorder MockWriter
 
 // Write(p []byte) (n int, err error)
-func (r_ *MockWriterMockRecorder) Write(p interface{}) MockWriterWriteCall {
+func (r_ *MockWriterMockRecorder) Write(p any) MockWriterWriteCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Write", reflect.TypeOf((*MockWriter)(nil).Write), p)
 	return MockWriterWriteCall{call}
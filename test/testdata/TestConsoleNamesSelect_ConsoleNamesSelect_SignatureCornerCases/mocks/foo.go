@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: pkg.Foo
+// Method set hash: Foo=0db4679e1f7605f3
+// Command: gmg --src pkg --dst ./foo.go --pkg mocks_pkg Foo
+// Body hash: 4df1e516a84c61e2
 
 package mocks_pkg
 
@@ -72,7 +75,7 @@ func (m_ *MockFoo) NoArgsAndResults() {
 // OnlyVariadicArgs implements mocked interface.
 func (m_ *MockFoo) OnlyVariadicArgs(as ...int) {
 	m_.ctrl.T.Helper()
-	args_ := []interface{}{}
+	args_ := []any{}
 	for _, a := range as {
 		args_ = append(args_, a)
 	}
@@ -112,7 +115,7 @@ func (m_ *MockFoo) UnderscoreArgsAndResults(arg int) (_ int) {
 // VariadicArgs implements mocked interface.
 func (m_ *MockFoo) VariadicArgs(f string, as ...int) {
 	m_.ctrl.T.Helper()
-	args_ := []interface{}{f}
+	args_ := []any{f}
 	for _, a := range as {
 		args_ = append(args_, a)
 	}
@@ -141,7 +144,7 @@ func (m_ *MockFoo) WellKnownNamesResults() (context.Context, *testing.T, error)
 type MockFooMockRecorder MockFoo
 
 // AfterOtherPackagesNamesArgs(context int)
-func (r_ *MockFooMockRecorder) AfterOtherPackagesNamesArgs(context2 interface{}) MockFooAfterOtherPackagesNamesArgsCall {
+func (r_ *MockFooMockRecorder) AfterOtherPackagesNamesArgs(context2 any) MockFooAfterOtherPackagesNamesArgsCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AfterOtherPackagesNamesArgs", reflect.TypeOf((*MockFoo)(nil).AfterOtherPackagesNamesArgs), context2)
 	return MockFooAfterOtherPackagesNamesArgsCall{call}
@@ -191,7 +194,7 @@ func (c_ MockFooAfterOtherPackagesNamesResultsCall) Return(context2 int) MockFoo
 }
 
 // BeforeOtherPackagesNamesArgs(testing int)
-func (r_ *MockFooMockRecorder) BeforeOtherPackagesNamesArgs(testing2 interface{}) MockFooBeforeOtherPackagesNamesArgsCall {
+func (r_ *MockFooMockRecorder) BeforeOtherPackagesNamesArgs(testing2 any) MockFooBeforeOtherPackagesNamesArgsCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "BeforeOtherPackagesNamesArgs", reflect.TypeOf((*MockFoo)(nil).BeforeOtherPackagesNamesArgs), testing2)
 	return MockFooBeforeOtherPackagesNamesArgsCall{call}
@@ -241,7 +244,7 @@ func (c_ MockFooBeforeOtherPackagesNamesResultsCall) Return(testing2 int) MockFo
 }
 
 // NamedArgsAndResults(a int) (b int)
-func (r_ *MockFooMockRecorder) NamedArgsAndResults(a interface{}) MockFooNamedArgsAndResultsCall {
+func (r_ *MockFooMockRecorder) NamedArgsAndResults(a any) MockFooNamedArgsAndResultsCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "NamedArgsAndResults", reflect.TypeOf((*MockFoo)(nil).NamedArgsAndResults), a)
 	return MockFooNamedArgsAndResultsCall{call}
@@ -291,7 +294,7 @@ func (c_ MockFooNoArgsAndResultsCall) Do(f func()) MockFooNoArgsAndResultsCall {
 }
 
 // OnlyVariadicArgs(as ...int)
-func (r_ *MockFooMockRecorder) OnlyVariadicArgs(as ...interface{}) MockFooOnlyVariadicArgsCall {
+func (r_ *MockFooMockRecorder) OnlyVariadicArgs(as ...any) MockFooOnlyVariadicArgsCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "OnlyVariadicArgs", reflect.TypeOf((*MockFoo)(nil).OnlyVariadicArgs), as...)
 	return MockFooOnlyVariadicArgsCall{call}
@@ -313,7 +316,7 @@ func (c_ MockFooOnlyVariadicArgsCall) Do(f func(as ...int)) MockFooOnlyVariadicA
 }
 
 // ReservedArgNames(c int, r int, m int, res int, call int, reflect int, gomock int)
-func (r_ *MockFooMockRecorder) ReservedArgNames(c interface{}, r interface{}, m interface{}, res interface{}, call interface{}, reflect2 interface{}, gomock2 interface{}) MockFooReservedArgNamesCall {
+func (r_ *MockFooMockRecorder) ReservedArgNames(c any, r any, m any, res any, call any, reflect2 any, gomock2 any) MockFooReservedArgNamesCall {
 	r_.ctrl.T.Helper()
 	call2 := r_.ctrl.RecordCallWithMethodType(r_.mock(), "ReservedArgNames", reflect.TypeOf((*MockFoo)(nil).ReservedArgNames), c, r, m, res, call, reflect2, gomock2)
 	return MockFooReservedArgNamesCall{call2}
@@ -363,7 +366,7 @@ func (c_ MockFooReservedResultNamesCall) Return(c int, r int, m int, res int, ca
 }
 
 // UnderscoreArgsAndResults(_ int) (_ int)
-func (r_ *MockFooMockRecorder) UnderscoreArgsAndResults(arg interface{}) MockFooUnderscoreArgsAndResultsCall {
+func (r_ *MockFooMockRecorder) UnderscoreArgsAndResults(arg any) MockFooUnderscoreArgsAndResultsCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "UnderscoreArgsAndResults", reflect.TypeOf((*MockFoo)(nil).UnderscoreArgsAndResults), arg)
 	return MockFooUnderscoreArgsAndResultsCall{call}
@@ -391,9 +394,9 @@ func (c_ MockFooUnderscoreArgsAndResultsCall) Return(res0 int) MockFooUnderscore
 }
 
 // VariadicArgs(f string, as ...int)
-func (r_ *MockFooMockRecorder) VariadicArgs(f interface{}, as ...interface{}) MockFooVariadicArgsCall {
+func (r_ *MockFooMockRecorder) VariadicArgs(f any, as ...any) MockFooVariadicArgsCall {
 	r_.ctrl.T.Helper()
-	args_ := append([]interface{}{f}, as...)
+	args_ := append([]any{f}, as...)
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "VariadicArgs", reflect.TypeOf((*MockFoo)(nil).VariadicArgs), args_...)
 	return MockFooVariadicArgsCall{call}
 }
@@ -414,7 +417,7 @@ func (c_ MockFooVariadicArgsCall) Do(f func(f string, as ...int)) MockFooVariadi
 }
 
 // WellKnownNamesArgs(context.Context, *testing.T, error)
-func (r_ *MockFooMockRecorder) WellKnownNamesArgs(arg interface{}, arg2 interface{}, arg3 interface{}) MockFooWellKnownNamesArgsCall {
+func (r_ *MockFooMockRecorder) WellKnownNamesArgs(arg any, arg2 any, arg3 any) MockFooWellKnownNamesArgsCall {
 	r_.ctrl.T.Helper()
 	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "WellKnownNamesArgs", reflect.TypeOf((*MockFoo)(nil).WellKnownNamesArgs), arg, arg2, arg3)
 	return MockFooWellKnownNamesArgsCall{call}
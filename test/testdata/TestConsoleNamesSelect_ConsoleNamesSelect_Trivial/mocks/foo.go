@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: pkg.Foo
+// Method set hash: Foo=288713938ff2bb7b
+// Command: gmg --src pkg --dst ./foo.go --pkg mocks_pkg Foo
+// Body hash: a3c1372bfb8d6988
 
 package mocks_pkg
 
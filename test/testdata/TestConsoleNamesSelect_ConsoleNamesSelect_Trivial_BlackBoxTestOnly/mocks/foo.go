@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: pkg_test.Foo
+// Method set hash: Foo=288713938ff2bb7b
+// Command: gmg --src pkg --dst ./foo.go --pkg mocks_mypkg Foo
+// Body hash: b27a6783d630b030
 
 package mocks_mypkg
 
@@ -0,0 +1,62 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: repo/pkg/a_test.ATest
+// Method set hash: ATest=3fbe70fa5d95420e
+// Command: gmg --src repo/pkg/a --dst ./mocks_test.go --pkg a ATest
+// Body hash: a56f99f99bc79f49
+
+package a
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// NewMockATest creates a new GoMock for repo/pkg/a_test.ATest.
+func NewMockATest(ctrl *gomock.Controller) *MockATest {
+	return &MockATest{ctrl: ctrl}
+}
+
+// MockATest is a GoMock of repo/pkg/a_test.ATest.
+type MockATest struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockATest) EXPECT() *MockATestMockRecorder {
+	return (*MockATestMockRecorder)(m_)
+}
+
+// AT implements mocked interface.
+func (m_ *MockATest) AT() {
+	m_.ctrl.T.Helper()
+	m_.ctrl.Call(m_, "AT")
+	return
+}
+
+// MockATestMockRecorder is the mock recorder for MockATest.
+type MockATestMockRecorder MockATest
+
+// AT()
+func (r_ *MockATestMockRecorder) AT() MockATestATCall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "AT", reflect.TypeOf((*MockATest)(nil).AT))
+	return MockATestATCall{call}
+}
+
+// MockATestATCall is type safe wrapper of *gomock.Call.
+type MockATestATCall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockATestATCall) DoAndReturn(f func()) MockATestATCall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockATestATCall) Do(f func()) MockATestATCall {
+	c_.Call.Do(f)
+	return c_
+}
+
+func (r_ *MockATestMockRecorder) mock() *MockATest {
+	return (*MockATest)(r_)
+}
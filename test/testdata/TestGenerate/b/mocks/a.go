@@ -0,0 +1,40 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: repo/pkg/a.A
+// Method set hash: A=636daebd163ef666
+// Command: gmg --src repo/pkg/a --dst ./a.go --pkg mocks_a --kind logging A
+// Body hash: 7669f4c3ccb78d4a
+
+package mocks_a
+
+import (
+	context "context"
+	slog "log/slog"
+	pkg "repo/pkg"
+	a "repo/pkg/a"
+	time "time"
+)
+
+// NewLoggingA creates a new LoggingA, that calls next and logs calls to log.
+func NewLoggingA(next a.A, log *slog.Logger) *LoggingA {
+	return &LoggingA{next: next, log: log}
+}
+
+// LoggingA is a logging decorator of repo/pkg/a.A.
+// Successful calls are logged with info level, and calls that returned non-nil error with error level.
+type LoggingA struct {
+	next a.A
+	log  *slog.Logger
+}
+
+// A implements repo/pkg/a.A.
+func (d_ *LoggingA) A() pkg.Foo {
+	start_ := time.Now()
+	res0 := d_.next.A()
+	attrs_ := []slog.Attr{
+		slog.Any("res0", res0),
+		slog.Duration("duration", time.Since(start_)),
+	}
+	level_ := slog.LevelInfo
+	d_.log.LogAttrs(context.Background(), level_, "A.A", attrs_...)
+	return res0
+}
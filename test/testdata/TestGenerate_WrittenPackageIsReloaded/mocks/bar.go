@@ -0,0 +1,62 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: repo/pkg.Bar
+// Method set hash: Bar=e74e31a1fd97aacd
+// Command: gmg --src repo/pkg --dst ./bar.go --pkg fakes Bar
+// Body hash: 9a473fd21b038cc1
+
+package fakes
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// NewMockBar creates a new GoMock for repo/pkg.Bar.
+func NewMockBar(ctrl *gomock.Controller) *MockBar {
+	return &MockBar{ctrl: ctrl}
+}
+
+// MockBar is a GoMock of repo/pkg.Bar.
+type MockBar struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockBar) EXPECT() *MockBarMockRecorder {
+	return (*MockBarMockRecorder)(m_)
+}
+
+// Bar implements mocked interface.
+func (m_ *MockBar) Bar() {
+	m_.ctrl.T.Helper()
+	m_.ctrl.Call(m_, "Bar")
+	return
+}
+
+// MockBarMockRecorder is the mock recorder for MockBar.
+type MockBarMockRecorder MockBar
+
+// Bar()
+func (r_ *MockBarMockRecorder) Bar() MockBarBarCall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Bar", reflect.TypeOf((*MockBar)(nil).Bar))
+	return MockBarBarCall{call}
+}
+
+// MockBarBarCall is type safe wrapper of *gomock.Call.
+type MockBarBarCall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockBarBarCall) DoAndReturn(f func()) MockBarBarCall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockBarBarCall) Do(f func()) MockBarBarCall {
+	c_.Call.Do(f)
+	return c_
+}
+
+func (r_ *MockBarMockRecorder) mock() *MockBar {
+	return (*MockBar)(r_)
+}
@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: pkg.Foo
+// Method set hash: Foo=288713938ff2bb7b
+// Command: gmg --src pkg --dst ./foo.go --pkg custom_mocks_dir_package Foo
+// Body hash: 07277f34780bf9f0
 
 package custom_mocks_dir_package
 
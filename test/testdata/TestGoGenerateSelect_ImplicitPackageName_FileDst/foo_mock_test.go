@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: pkg.Foo
+// Method set hash: Foo=288713938ff2bb7b
+// Command: gmg --src pkg --dst ./foo_mock_test.go --pkg pkg Foo
+// Body hash: fd351e3999d80ca3
 
 package pkg
 
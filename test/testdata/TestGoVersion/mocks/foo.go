@@ -0,0 +1,67 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: repo/pkg.Foo
+// Method set hash: Foo=55caab7d2c750801
+// Command: gmg --src repo/pkg --dst ./foo.go --pkg mocks_pkg Foo
+// Body hash: a670403571d7f67b
+
+package mocks_pkg
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// NewMockFoo creates a new GoMock for repo/pkg.Foo.
+func NewMockFoo(ctrl *gomock.Controller) *MockFoo {
+	return &MockFoo{ctrl: ctrl}
+}
+
+// MockFoo is a GoMock of repo/pkg.Foo.
+type MockFoo struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockFoo) EXPECT() *MockFooMockRecorder {
+	return (*MockFooMockRecorder)(m_)
+}
+
+// Foo implements mocked interface.
+func (m_ *MockFoo) Foo(a int, b ...string) {
+	m_.ctrl.T.Helper()
+	args_ := []interface{}{a}
+	for _, a := range b {
+		args_ = append(args_, a)
+	}
+	m_.ctrl.Call(m_, "Foo", args_...)
+	return
+}
+
+// MockFooMockRecorder is the mock recorder for MockFoo.
+type MockFooMockRecorder MockFoo
+
+// Foo(a int, b ...string)
+func (r_ *MockFooMockRecorder) Foo(a interface{}, b ...interface{}) MockFooFooCall {
+	r_.ctrl.T.Helper()
+	args_ := append([]interface{}{a}, b...)
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Foo", reflect.TypeOf((*MockFoo)(nil).Foo), args_...)
+	return MockFooFooCall{call}
+}
+
+// MockFooFooCall is type safe wrapper of *gomock.Call.
+type MockFooFooCall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockFooFooCall) DoAndReturn(f func(a int, b ...string)) MockFooFooCall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockFooFooCall) Do(f func(a int, b ...string)) MockFooFooCall {
+	c_.Call.Do(f)
+	return c_
+}
+
+func (r_ *MockFooMockRecorder) mock() *MockFoo {
+	return (*MockFoo)(r_)
+}
@@ -0,0 +1,94 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: pkg.Foo
+// Method set hash: Foo=5c9af4f4ab0d2490
+// Command: gmg --src pkg --dst ./foo.go --pkg mocks_pkg --kind faulty Foo
+// Body hash: 21db3290e83f0cdd
+
+package mocks_pkg
+
+import (
+	context "context"
+	fmt "fmt"
+	rand "math/rand"
+	pkg "pkg"
+	time "time"
+)
+
+// FaultyFooPolicy configures faults, that FaultyFoo injects.
+// Zero policy injects nothing.
+type FaultyFooPolicy struct {
+	// ErrorRate is probability in [0, 1] of returning error instead of calling wrapped implementation.
+	ErrorRate float64
+	// Err is injected error. If it is nil, then error with method name is injected.
+	Err error
+	// Latency is added before every call.
+	Latency time.Duration
+	// Override, if set, is called before every call with method name, instead of ErrorRate check.
+	// Returned non-nil error is injected. For methods without error result, returned error is ignored.
+	Override func(method string) error
+}
+
+// NewFaultyFoo creates a new FaultyFoo, that calls next and injects faults according to policy.
+func NewFaultyFoo(next pkg.Foo, policy FaultyFooPolicy) *FaultyFoo {
+	return &FaultyFoo{next: next, policy: policy}
+}
+
+// FaultyFoo is a fault injecting decorator of pkg.Foo.
+// Errors are injected only into methods, which last result is error.
+type FaultyFoo struct {
+	next   pkg.Foo
+	policy FaultyFooPolicy
+}
+
+func (d_ *FaultyFoo) fault(method string) error {
+	if d_.policy.Latency > 0 {
+		time.Sleep(d_.policy.Latency)
+	}
+	if d_.policy.Override != nil {
+		return d_.policy.Override(method)
+	}
+	if d_.policy.ErrorRate <= 0 || rand.Float64() >= d_.policy.ErrorRate {
+		return nil
+	}
+	if d_.policy.Err != nil {
+		return d_.policy.Err
+	}
+	return fmt.Errorf("Foo.%s: injected fault", method)
+}
+
+// Context implements pkg.Foo.
+func (d_ *FaultyFoo) Context(ctx context.Context, a int) (string, error) {
+	if err_ := d_.fault("Context"); err_ != nil {
+		var res0 string
+		return res0, err_
+	}
+	return d_.next.Context(ctx, a)
+}
+
+// NamedResults implements pkg.Foo.
+func (d_ *FaultyFoo) NamedResults(a int) (b int, err error) {
+	if err_ := d_.fault("NamedResults"); err_ != nil {
+		return b, err_
+	}
+	return d_.next.NamedResults(a)
+}
+
+// NoArgsAndResults implements pkg.Foo.
+func (d_ *FaultyFoo) NoArgsAndResults() {
+	_ = d_.fault("NoArgsAndResults")
+	d_.next.NoArgsAndResults()
+}
+
+// NoError implements pkg.Foo.
+func (d_ *FaultyFoo) NoError(a int) string {
+	_ = d_.fault("NoError")
+	return d_.next.NoError(a)
+}
+
+// Variadic implements pkg.Foo.
+func (d_ *FaultyFoo) Variadic(f string, as ...int) error {
+	if err_ := d_.fault("Variadic"); err_ != nil {
+		return err_
+	}
+	return d_.next.Variadic(f, as...)
+}
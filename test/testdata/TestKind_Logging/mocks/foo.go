@@ -0,0 +1,125 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: pkg.Foo
+// Method set hash: Foo=370f17e914b4deb0
+// Command: gmg --src pkg --dst ./foo.go --pkg mocks_pkg --kind logging Foo
+// Body hash: c11ec976a6510dc3
+
+package mocks_pkg
+
+import (
+	context "context"
+	slog "log/slog"
+	pkg "pkg"
+	time "time"
+)
+
+// NewLoggingFoo creates a new LoggingFoo, that calls next and logs calls to log.
+func NewLoggingFoo(next pkg.Foo, log *slog.Logger) *LoggingFoo {
+	return &LoggingFoo{next: next, log: log}
+}
+
+// LoggingFoo is a logging decorator of pkg.Foo.
+// Successful calls are logged with info level, and calls that returned non-nil error with error level.
+type LoggingFoo struct {
+	next pkg.Foo
+	log  *slog.Logger
+}
+
+// Context implements pkg.Foo.
+func (d_ *LoggingFoo) Context(ctx context.Context, a int) (string, error) {
+	start_ := time.Now()
+	res0, res1 := d_.next.Context(ctx, a)
+	attrs_ := []slog.Attr{
+		slog.Any("a", a),
+		slog.Any("res0", res0),
+		slog.Duration("duration", time.Since(start_)),
+	}
+	level_ := slog.LevelInfo
+	if res1 != nil {
+		level_ = slog.LevelError
+		attrs_ = append(attrs_, slog.Any("error", res1))
+	}
+	d_.log.LogAttrs(ctx, level_, "Foo.Context", attrs_...)
+	return res0, res1
+}
+
+// Login implements pkg.Foo.
+func (d_ *LoggingFoo) Login(user string, password string) error {
+	start_ := time.Now()
+	res0 := d_.next.Login(user, password)
+	attrs_ := []slog.Attr{
+		slog.Any("user", user),
+		slog.String("password", "REDACTED"),
+		slog.Duration("duration", time.Since(start_)),
+	}
+	level_ := slog.LevelInfo
+	if res0 != nil {
+		level_ = slog.LevelError
+		attrs_ = append(attrs_, slog.Any("error", res0))
+	}
+	d_.log.LogAttrs(context.Background(), level_, "Foo.Login", attrs_...)
+	return res0
+}
+
+// NamedResults implements pkg.Foo.
+func (d_ *LoggingFoo) NamedResults(a int) (b int, err error) {
+	start_ := time.Now()
+	b, err = d_.next.NamedResults(a)
+	attrs_ := []slog.Attr{
+		slog.Any("a", a),
+		slog.Any("b", b),
+		slog.Duration("duration", time.Since(start_)),
+	}
+	level_ := slog.LevelInfo
+	if err != nil {
+		level_ = slog.LevelError
+		attrs_ = append(attrs_, slog.Any("error", err))
+	}
+	d_.log.LogAttrs(context.Background(), level_, "Foo.NamedResults", attrs_...)
+	return b, err
+}
+
+// NoArgsAndResults implements pkg.Foo.
+func (d_ *LoggingFoo) NoArgsAndResults() {
+	start_ := time.Now()
+	d_.next.NoArgsAndResults()
+	attrs_ := []slog.Attr{
+		slog.Duration("duration", time.Since(start_)),
+	}
+	level_ := slog.LevelInfo
+	d_.log.LogAttrs(context.Background(), level_, "Foo.NoArgsAndResults", attrs_...)
+	return
+}
+
+// Token implements pkg.Foo.
+func (d_ *LoggingFoo) Token(user string, token string) bool {
+	start_ := time.Now()
+	res0 := d_.next.Token(user, token)
+	attrs_ := []slog.Attr{
+		slog.Any("user", user),
+		slog.String("token", "REDACTED"),
+		slog.Any("res0", res0),
+		slog.Duration("duration", time.Since(start_)),
+	}
+	level_ := slog.LevelInfo
+	d_.log.LogAttrs(context.Background(), level_, "Foo.Token", attrs_...)
+	return res0
+}
+
+// Variadic implements pkg.Foo.
+func (d_ *LoggingFoo) Variadic(f string, as ...int) error {
+	start_ := time.Now()
+	res0 := d_.next.Variadic(f, as...)
+	attrs_ := []slog.Attr{
+		slog.Any("f", f),
+		slog.Any("as", as),
+		slog.Duration("duration", time.Since(start_)),
+	}
+	level_ := slog.LevelInfo
+	if res0 != nil {
+		level_ = slog.LevelError
+		attrs_ = append(attrs_, slog.Any("error", res0))
+	}
+	d_.log.LogAttrs(context.Background(), level_, "Foo.Variadic", attrs_...)
+	return res0
+}
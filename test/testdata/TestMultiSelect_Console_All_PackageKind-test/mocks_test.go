@@ -0,0 +1,118 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: repo/pkg.Test1,Test2
+// Method set hash: Test1=9304507cf1266a84 Test2=fccbbfc3cd896631
+// Command: gmg --src repo/pkg --dst ./mocks_test.go --pkg pkg Test1 Test2
+// Body hash: e46db14161a17e6d
+
+package pkg
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// NewMockTest1 creates a new GoMock for repo/pkg.Test1.
+func NewMockTest1(ctrl *gomock.Controller) *MockTest1 {
+	return &MockTest1{ctrl: ctrl}
+}
+
+// MockTest1 is a GoMock of repo/pkg.Test1.
+type MockTest1 struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockTest1) EXPECT() *MockTest1MockRecorder {
+	return (*MockTest1MockRecorder)(m_)
+}
+
+// T1 implements mocked interface.
+func (m_ *MockTest1) T1() Primary1 {
+	m_.ctrl.T.Helper()
+	res_ := m_.ctrl.Call(m_, "T1")
+	res0, _ := res_[0].(Primary1)
+	return res0
+}
+
+// MockTest1MockRecorder is the mock recorder for MockTest1.
+type MockTest1MockRecorder MockTest1
+
+// T1() pkg.Primary1
+func (r_ *MockTest1MockRecorder) T1() MockTest1T1Call {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "T1", reflect.TypeOf((*MockTest1)(nil).T1))
+	return MockTest1T1Call{call}
+}
+
+// MockTest1T1Call is type safe wrapper of *gomock.Call.
+type MockTest1T1Call struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockTest1T1Call) DoAndReturn(f func() Primary1) MockTest1T1Call {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockTest1T1Call) Do(f func()) MockTest1T1Call {
+	c_.Call.Do(f)
+	return c_
+}
+
+// Return is type safe wrapper of *gomock.Call Return.
+func (c_ MockTest1T1Call) Return(res0 Primary1) MockTest1T1Call {
+	c_.Call.Return(res0)
+	return c_
+}
+
+func (r_ *MockTest1MockRecorder) mock() *MockTest1 {
+	return (*MockTest1)(r_)
+}
+
+// NewMockTest2 creates a new GoMock for repo/pkg.Test2.
+func NewMockTest2(ctrl *gomock.Controller) *MockTest2 {
+	return &MockTest2{ctrl: ctrl}
+}
+
+// MockTest2 is a GoMock of repo/pkg.Test2.
+type MockTest2 struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockTest2) EXPECT() *MockTest2MockRecorder {
+	return (*MockTest2MockRecorder)(m_)
+}
+
+// T2 implements mocked interface.
+func (m_ *MockTest2) T2() {
+	m_.ctrl.T.Helper()
+	m_.ctrl.Call(m_, "T2")
+	return
+}
+
+// MockTest2MockRecorder is the mock recorder for MockTest2.
+type MockTest2MockRecorder MockTest2
+
+// T2()
+func (r_ *MockTest2MockRecorder) T2() MockTest2T2Call {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "T2", reflect.TypeOf((*MockTest2)(nil).T2))
+	return MockTest2T2Call{call}
+}
+
+// MockTest2T2Call is type safe wrapper of *gomock.Call.
+type MockTest2T2Call struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockTest2T2Call) DoAndReturn(f func()) MockTest2T2Call {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockTest2T2Call) Do(f func()) MockTest2T2Call {
+	c_.Call.Do(f)
+	return c_
+}
+
+func (r_ *MockTest2MockRecorder) mock() *MockTest2 {
+	return (*MockTest2)(r_)
+}
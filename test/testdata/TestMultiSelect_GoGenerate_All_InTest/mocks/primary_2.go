@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: repo/pkg.Primary2
+// Method set hash: Primary2=37c42d097ed257eb
+// Command: gmg --src repo/pkg --dst ./primary_2.go --pkg mocks_pkg Primary2
+// Body hash: f707b9c0c2644a88
 
 package mocks_pkg
 
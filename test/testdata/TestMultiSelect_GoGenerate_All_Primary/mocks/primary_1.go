@@ -1,5 +1,8 @@
 // Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
 // Source: repo/pkg.Primary1
+// Method set hash: Primary1=4b1a1143ecfa5f71
+// Command: gmg --src repo/pkg --dst ./primary_1.go --pkg mocks_pkg Primary1
+// Body hash: d653df97e788ecd4
 
 package mocks_pkg
 
@@ -0,0 +1,70 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: repo/pkg/a.A
+// Method set hash: A=26f6d056b76a5eca
+// Command: gmg --src repo/pkg/a --dst ./a.go --pkg mocks_a A
+// Body hash: 7cef05cdfe30b592
+
+package mocks_a
+
+import (
+	reflect "reflect"
+	b "repo/pkg/b"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// NewMockA creates a new GoMock for repo/pkg/a.A.
+func NewMockA(ctrl *gomock.Controller) *MockA {
+	return &MockA{ctrl: ctrl}
+}
+
+// MockA is a GoMock of repo/pkg/a.A.
+type MockA struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockA) EXPECT() *MockAMockRecorder {
+	return (*MockAMockRecorder)(m_)
+}
+
+// A implements mocked interface.
+func (m_ *MockA) A() b.B {
+	m_.ctrl.T.Helper()
+	res_ := m_.ctrl.Call(m_, "A")
+	res0, _ := res_[0].(b.B)
+	return res0
+}
+
+// MockAMockRecorder is the mock recorder for MockA.
+type MockAMockRecorder MockA
+
+// A() b.B
+func (r_ *MockAMockRecorder) A() MockAACall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "A", reflect.TypeOf((*MockA)(nil).A))
+	return MockAACall{call}
+}
+
+// MockAACall is type safe wrapper of *gomock.Call.
+type MockAACall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockAACall) DoAndReturn(f func() b.B) MockAACall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockAACall) Do(f func()) MockAACall {
+	c_.Call.Do(f)
+	return c_
+}
+
+// Return is type safe wrapper of *gomock.Call Return.
+func (c_ MockAACall) Return(res0 b.B) MockAACall {
+	c_.Call.Return(res0)
+	return c_
+}
+
+func (r_ *MockAMockRecorder) mock() *MockA {
+	return (*MockA)(r_)
+}
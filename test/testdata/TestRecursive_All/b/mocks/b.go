@@ -0,0 +1,62 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: repo/pkg/b.B
+// Method set hash: B=1c1226584817d8ac
+// Command: gmg --src repo/pkg/b --dst ./b.go --pkg mocks B
+// Body hash: 1ce4b927e35c3414
+
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// NewMockB creates a new GoMock for repo/pkg/b.B.
+func NewMockB(ctrl *gomock.Controller) *MockB {
+	return &MockB{ctrl: ctrl}
+}
+
+// MockB is a GoMock of repo/pkg/b.B.
+type MockB struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockB) EXPECT() *MockBMockRecorder {
+	return (*MockBMockRecorder)(m_)
+}
+
+// B implements mocked interface.
+func (m_ *MockB) B() {
+	m_.ctrl.T.Helper()
+	m_.ctrl.Call(m_, "B")
+	return
+}
+
+// MockBMockRecorder is the mock recorder for MockB.
+type MockBMockRecorder MockB
+
+// B()
+func (r_ *MockBMockRecorder) B() MockBBCall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "B", reflect.TypeOf((*MockB)(nil).B))
+	return MockBBCall{call}
+}
+
+// MockBBCall is type safe wrapper of *gomock.Call.
+type MockBBCall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockBBCall) DoAndReturn(f func()) MockBBCall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockBBCall) Do(f func()) MockBBCall {
+	c_.Call.Do(f)
+	return c_
+}
+
+func (r_ *MockBMockRecorder) mock() *MockB {
+	return (*MockB)(r_)
+}
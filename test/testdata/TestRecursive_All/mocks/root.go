@@ -0,0 +1,62 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: repo/pkg.Root
+// Method set hash: Root=a8a31bfa7dd0793f
+// Command: gmg --src repo/pkg --dst ./root.go --pkg mocks_pkg Root
+// Body hash: a75a0550aed097d3
+
+package mocks_pkg
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// NewMockRoot creates a new GoMock for repo/pkg.Root.
+func NewMockRoot(ctrl *gomock.Controller) *MockRoot {
+	return &MockRoot{ctrl: ctrl}
+}
+
+// MockRoot is a GoMock of repo/pkg.Root.
+type MockRoot struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockRoot) EXPECT() *MockRootMockRecorder {
+	return (*MockRootMockRecorder)(m_)
+}
+
+// R implements mocked interface.
+func (m_ *MockRoot) R() {
+	m_.ctrl.T.Helper()
+	m_.ctrl.Call(m_, "R")
+	return
+}
+
+// MockRootMockRecorder is the mock recorder for MockRoot.
+type MockRootMockRecorder MockRoot
+
+// R()
+func (r_ *MockRootMockRecorder) R() MockRootRCall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "R", reflect.TypeOf((*MockRoot)(nil).R))
+	return MockRootRCall{call}
+}
+
+// MockRootRCall is type safe wrapper of *gomock.Call.
+type MockRootRCall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockRootRCall) DoAndReturn(f func()) MockRootRCall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockRootRCall) Do(f func()) MockRootRCall {
+	c_.Call.Do(f)
+	return c_
+}
+
+func (r_ *MockRootMockRecorder) mock() *MockRoot {
+	return (*MockRoot)(r_)
+}
@@ -0,0 +1,111 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: repo/pkg/a.A1,A2
+// Method set hash: A1=cd7b59b3b3d93038 A2=4cc751db54420139
+// Command: gmg --src repo/pkg/a --dst ./mocks_test.go --pkg a A1 A2
+// Body hash: 60678819d34193d1
+
+package a
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// NewMockA1 creates a new GoMock for repo/pkg/a.A1.
+func NewMockA1(ctrl *gomock.Controller) *MockA1 {
+	return &MockA1{ctrl: ctrl}
+}
+
+// MockA1 is a GoMock of repo/pkg/a.A1.
+type MockA1 struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockA1) EXPECT() *MockA1MockRecorder {
+	return (*MockA1MockRecorder)(m_)
+}
+
+// A1 implements mocked interface.
+func (m_ *MockA1) A1() {
+	m_.ctrl.T.Helper()
+	m_.ctrl.Call(m_, "A1")
+	return
+}
+
+// MockA1MockRecorder is the mock recorder for MockA1.
+type MockA1MockRecorder MockA1
+
+// A1()
+func (r_ *MockA1MockRecorder) A1() MockA1A1Call {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "A1", reflect.TypeOf((*MockA1)(nil).A1))
+	return MockA1A1Call{call}
+}
+
+// MockA1A1Call is type safe wrapper of *gomock.Call.
+type MockA1A1Call struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockA1A1Call) DoAndReturn(f func()) MockA1A1Call {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockA1A1Call) Do(f func()) MockA1A1Call {
+	c_.Call.Do(f)
+	return c_
+}
+
+func (r_ *MockA1MockRecorder) mock() *MockA1 {
+	return (*MockA1)(r_)
+}
+
+// NewMockA2 creates a new GoMock for repo/pkg/a.A2.
+func NewMockA2(ctrl *gomock.Controller) *MockA2 {
+	return &MockA2{ctrl: ctrl}
+}
+
+// MockA2 is a GoMock of repo/pkg/a.A2.
+type MockA2 struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockA2) EXPECT() *MockA2MockRecorder {
+	return (*MockA2MockRecorder)(m_)
+}
+
+// A2 implements mocked interface.
+func (m_ *MockA2) A2() {
+	m_.ctrl.T.Helper()
+	m_.ctrl.Call(m_, "A2")
+	return
+}
+
+// MockA2MockRecorder is the mock recorder for MockA2.
+type MockA2MockRecorder MockA2
+
+// A2()
+func (r_ *MockA2MockRecorder) A2() MockA2A2Call {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "A2", reflect.TypeOf((*MockA2)(nil).A2))
+	return MockA2A2Call{call}
+}
+
+// MockA2A2Call is type safe wrapper of *gomock.Call.
+type MockA2A2Call struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockA2A2Call) DoAndReturn(f func()) MockA2A2Call {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockA2A2Call) Do(f func()) MockA2A2Call {
+	c_.Call.Do(f)
+	return c_
+}
+
+func (r_ *MockA2MockRecorder) mock() *MockA2 {
+	return (*MockA2)(r_)
+}
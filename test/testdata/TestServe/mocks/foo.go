@@ -0,0 +1,91 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: repo/pkg.Foo
+// Method set hash: Foo=95c7842be4923792
+// Command: gmg --src repo/pkg --dst ./foo.go --pkg mocks_pkg Foo
+// Body hash: bad7e95bc0664351
+
+package mocks_pkg
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// NewMockFoo creates a new GoMock for repo/pkg.Foo.
+func NewMockFoo(ctrl *gomock.Controller) *MockFoo {
+	return &MockFoo{ctrl: ctrl}
+}
+
+// MockFoo is a GoMock of repo/pkg.Foo.
+type MockFoo struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockFoo) EXPECT() *MockFooMockRecorder {
+	return (*MockFooMockRecorder)(m_)
+}
+
+// Bar implements mocked interface.
+func (m_ *MockFoo) Bar() {
+	m_.ctrl.T.Helper()
+	m_.ctrl.Call(m_, "Bar")
+	return
+}
+
+// Foo implements mocked interface.
+func (m_ *MockFoo) Foo() {
+	m_.ctrl.T.Helper()
+	m_.ctrl.Call(m_, "Foo")
+	return
+}
+
+// MockFooMockRecorder is the mock recorder for MockFoo.
+type MockFooMockRecorder MockFoo
+
+// Bar()
+func (r_ *MockFooMockRecorder) Bar() MockFooBarCall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Bar", reflect.TypeOf((*MockFoo)(nil).Bar))
+	return MockFooBarCall{call}
+}
+
+// MockFooBarCall is type safe wrapper of *gomock.Call.
+type MockFooBarCall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockFooBarCall) DoAndReturn(f func()) MockFooBarCall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockFooBarCall) Do(f func()) MockFooBarCall {
+	c_.Call.Do(f)
+	return c_
+}
+
+// Foo()
+func (r_ *MockFooMockRecorder) Foo() MockFooFooCall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Foo", reflect.TypeOf((*MockFoo)(nil).Foo))
+	return MockFooFooCall{call}
+}
+
+// MockFooFooCall is type safe wrapper of *gomock.Call.
+type MockFooFooCall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockFooFooCall) DoAndReturn(f func()) MockFooFooCall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockFooFooCall) Do(f func()) MockFooFooCall {
+	c_.Call.Do(f)
+	return c_
+}
+
+func (r_ *MockFooMockRecorder) mock() *MockFoo {
+	return (*MockFoo)(r_)
+}
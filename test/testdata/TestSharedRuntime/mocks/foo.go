@@ -0,0 +1,117 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: repo/pkg.Foo
+// Method set hash: Foo=87a5fcf07091c2a7
+// Command: gmg --src repo/pkg --dst ./foo.go --pkg mocks_pkg --shared-runtime Foo
+// Body hash: 71a4a50246bddaf0
+
+package mocks_pkg
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	gmgrt "github.com/skipor/gmg/pkg/gmgrt"
+)
+
+// NewMockFoo creates a new GoMock for repo/pkg.Foo.
+func NewMockFoo(ctrl *gomock.Controller) *MockFoo {
+	return &MockFoo{ctrl: ctrl}
+}
+
+// MockFoo is a GoMock of repo/pkg.Foo.
+type MockFoo struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockFoo) EXPECT() *MockFooMockRecorder {
+	return (*MockFooMockRecorder)(m_)
+}
+
+// Four implements mocked interface.
+func (m_ *MockFoo) Four(args ...int) (int, string, bool, error) {
+	m_.ctrl.T.Helper()
+	args_ := []any{}
+	for _, a := range args {
+		args_ = append(args_, a)
+	}
+	res_ := m_.ctrl.Call(m_, "Four", args_...)
+	res0, _ := res_[0].(int)
+	res1, _ := res_[1].(string)
+	res2, _ := res_[2].(bool)
+	res3, _ := res_[3].(error)
+	return res0, res1, res2, res3
+}
+
+// Many implements mocked interface.
+func (m_ *MockFoo) Many() (a int, b int, c int, d int, e int) {
+	m_.ctrl.T.Helper()
+	res_ := m_.ctrl.Call(m_, "Many")
+	a, _ = res_[0].(int)
+	b, _ = res_[1].(int)
+	c, _ = res_[2].(int)
+	d, _ = res_[3].(int)
+	e, _ = res_[4].(int)
+	return a, b, c, d, e
+}
+
+// None implements mocked interface.
+func (m_ *MockFoo) None(w io.Writer, arg int) {
+	m_.ctrl.T.Helper()
+	m_.ctrl.Call(m_, "None", w, arg)
+	return
+}
+
+// MockFooMockRecorder is the mock recorder for MockFoo.
+type MockFooMockRecorder MockFoo
+
+// Four(args ...int) (int, string, bool, error)
+func (r_ *MockFooMockRecorder) Four(args ...any) MockFooFourCall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Four", reflect.TypeOf((*MockFoo)(nil).Four), args...)
+	return MockFooFourCall{Call: call}
+}
+
+// MockFooFourCall is type safe wrapper of *gomock.Call.
+type MockFooFourCall = gmgrt.Call4[func(args ...int) (int, string, bool, error), func(args ...int), int, string, bool, error]
+
+// Many() (a int, b int, c int, d int, e int)
+func (r_ *MockFooMockRecorder) Many() MockFooManyCall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Many", reflect.TypeOf((*MockFoo)(nil).Many))
+	return MockFooManyCall{call}
+}
+
+// MockFooManyCall is type safe wrapper of *gomock.Call.
+type MockFooManyCall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockFooManyCall) DoAndReturn(f func() (a int, b int, c int, d int, e int)) MockFooManyCall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockFooManyCall) Do(f func()) MockFooManyCall {
+	c_.Call.Do(f)
+	return c_
+}
+
+// Return is type safe wrapper of *gomock.Call Return.
+func (c_ MockFooManyCall) Return(a int, b int, c int, d int, e int) MockFooManyCall {
+	c_.Call.Return(a, b, c, d, e)
+	return c_
+}
+
+// None(w io.Writer, _ int)
+func (r_ *MockFooMockRecorder) None(w any, arg any) MockFooNoneCall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "None", reflect.TypeOf((*MockFoo)(nil).None), w, arg)
+	return MockFooNoneCall{Call: call}
+}
+
+// MockFooNoneCall is type safe wrapper of *gomock.Call.
+type MockFooNoneCall = gmgrt.Call0[func(w io.Writer, arg int)]
+
+func (r_ *MockFooMockRecorder) mock() *MockFoo {
+	return (*MockFoo)(r_)
+}
@@ -0,0 +1,162 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: repo/pkg.Foo,Bar
+// Method set hash: Foo=851c7f03be3aceea Bar=289147b61919b0ba
+// Command: gmg --src repo/pkg --dst ./mocks_test.go --pkg pkg --mode source Foo Bar
+// Body hash: eb403b0f99ea3070
+
+package pkg
+
+import (
+	context "context"
+	reflect "reflect"
+	types "repo/pkg/types"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// NewMockFoo creates a new GoMock for repo/pkg.Foo.
+func NewMockFoo(ctrl *gomock.Controller) *MockFoo {
+	return &MockFoo{ctrl: ctrl}
+}
+
+// MockFoo is a GoMock of repo/pkg.Foo.
+type MockFoo struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockFoo) EXPECT() *MockFooMockRecorder {
+	return (*MockFooMockRecorder)(m_)
+}
+
+// Close implements mocked interface.
+func (m_ *MockFoo) Close() error {
+	m_.ctrl.T.Helper()
+	res_ := m_.ctrl.Call(m_, "Close")
+	res0, _ := res_[0].(error)
+	return res0
+}
+
+// Foo implements mocked interface.
+func (m_ *MockFoo) Foo(ctx context.Context, ts ...types.T) (map[string][2]types.T, error) {
+	m_.ctrl.T.Helper()
+	args_ := []any{ctx}
+	for _, a := range ts {
+		args_ = append(args_, a)
+	}
+	res_ := m_.ctrl.Call(m_, "Foo", args_...)
+	res0, _ := res_[0].(map[string][2]types.T)
+	res1, _ := res_[1].(error)
+	return res0, res1
+}
+
+// MockFooMockRecorder is the mock recorder for MockFoo.
+type MockFooMockRecorder MockFoo
+
+// Close() error
+func (r_ *MockFooMockRecorder) Close() MockFooCloseCall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Close", reflect.TypeOf((*MockFoo)(nil).Close))
+	return MockFooCloseCall{call}
+}
+
+// MockFooCloseCall is type safe wrapper of *gomock.Call.
+type MockFooCloseCall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockFooCloseCall) DoAndReturn(f func() error) MockFooCloseCall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockFooCloseCall) Do(f func()) MockFooCloseCall {
+	c_.Call.Do(f)
+	return c_
+}
+
+// Return is type safe wrapper of *gomock.Call Return.
+func (c_ MockFooCloseCall) Return(res0 error) MockFooCloseCall {
+	c_.Call.Return(res0)
+	return c_
+}
+
+// Foo(ctx context.Context, ts ...types.T) (map[string][2]types.T, error)
+func (r_ *MockFooMockRecorder) Foo(ctx any, ts ...any) MockFooFooCall {
+	r_.ctrl.T.Helper()
+	args_ := append([]any{ctx}, ts...)
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Foo", reflect.TypeOf((*MockFoo)(nil).Foo), args_...)
+	return MockFooFooCall{call}
+}
+
+// MockFooFooCall is type safe wrapper of *gomock.Call.
+type MockFooFooCall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockFooFooCall) DoAndReturn(f func(ctx context.Context, ts ...types.T) (map[string][2]types.T, error)) MockFooFooCall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockFooFooCall) Do(f func(ctx context.Context, ts ...types.T)) MockFooFooCall {
+	c_.Call.Do(f)
+	return c_
+}
+
+// Return is type safe wrapper of *gomock.Call Return.
+func (c_ MockFooFooCall) Return(res0 map[string][2]types.T, res1 error) MockFooFooCall {
+	c_.Call.Return(res0, res1)
+	return c_
+}
+
+func (r_ *MockFooMockRecorder) mock() *MockFoo {
+	return (*MockFoo)(r_)
+}
+
+// NewMockBar creates a new GoMock for repo/pkg.Bar.
+func NewMockBar(ctrl *gomock.Controller) *MockBar {
+	return &MockBar{ctrl: ctrl}
+}
+
+// MockBar is a GoMock of repo/pkg.Bar.
+type MockBar struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockBar) EXPECT() *MockBarMockRecorder {
+	return (*MockBarMockRecorder)(m_)
+}
+
+// Bar implements mocked interface.
+func (m_ *MockBar) Bar(arg Foo) {
+	m_.ctrl.T.Helper()
+	m_.ctrl.Call(m_, "Bar", arg)
+	return
+}
+
+// MockBarMockRecorder is the mock recorder for MockBar.
+type MockBarMockRecorder MockBar
+
+// Bar(pkg.Foo)
+func (r_ *MockBarMockRecorder) Bar(arg any) MockBarBarCall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Bar", reflect.TypeOf((*MockBar)(nil).Bar), arg)
+	return MockBarBarCall{call}
+}
+
+// MockBarBarCall is type safe wrapper of *gomock.Call.
+type MockBarBarCall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockBarBarCall) DoAndReturn(f func(arg Foo)) MockBarBarCall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockBarBarCall) Do(f func(arg Foo)) MockBarBarCall {
+	c_.Call.Do(f)
+	return c_
+}
+
+func (r_ *MockBarMockRecorder) mock() *MockBar {
+	return (*MockBar)(r_)
+}
@@ -0,0 +1,104 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: repo/pkg.Foo
+// Method set hash: Foo=351bed3f3ac4f3d8
+// Command: gmg --src repo/pkg --dst ./foo.go --pkg mocks_pkg --mode source Foo
+// Body hash: 449a3aab82591c25
+
+package mocks_pkg
+
+import (
+	io "io"
+	reflect "reflect"
+	pkg "repo/pkg"
+	broken "repo/pkg/broken"
+
+	missing "example.com/missing"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// NewMockFoo creates a new GoMock for repo/pkg.Foo.
+func NewMockFoo(ctrl *gomock.Controller) *MockFoo {
+	return &MockFoo{ctrl: ctrl}
+}
+
+// MockFoo is a GoMock of repo/pkg.Foo.
+type MockFoo struct{ ctrl *gomock.Controller }
+
+// EXPECT returns GoMock recorder.
+func (m_ *MockFoo) EXPECT() *MockFooMockRecorder {
+	return (*MockFooMockRecorder)(m_)
+}
+
+// Bar implements mocked interface.
+func (m_ *MockFoo) Bar(ch <-chan missing.Thing, f func(pkg.Undeclared) error) {
+	m_.ctrl.T.Helper()
+	m_.ctrl.Call(m_, "Bar", ch, f)
+	return
+}
+
+// Foo implements mocked interface.
+func (m_ *MockFoo) Foo(r io.Reader, m missing.Thing) (*missing.Other, []broken.T, error) {
+	m_.ctrl.T.Helper()
+	res_ := m_.ctrl.Call(m_, "Foo", r, m)
+	res0, _ := res_[0].(*missing.Other)
+	res1, _ := res_[1].([]broken.T)
+	res2, _ := res_[2].(error)
+	return res0, res1, res2
+}
+
+// MockFooMockRecorder is the mock recorder for MockFoo.
+type MockFooMockRecorder MockFoo
+
+// Bar(ch <-chan missing.Thing, f func(pkg.Undeclared) error)
+func (r_ *MockFooMockRecorder) Bar(ch any, f any) MockFooBarCall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Bar", reflect.TypeOf((*MockFoo)(nil).Bar), ch, f)
+	return MockFooBarCall{call}
+}
+
+// MockFooBarCall is type safe wrapper of *gomock.Call.
+type MockFooBarCall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockFooBarCall) DoAndReturn(f func(ch <-chan missing.Thing, f func(pkg.Undeclared) error)) MockFooBarCall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockFooBarCall) Do(f func(ch <-chan missing.Thing, f func(pkg.Undeclared) error)) MockFooBarCall {
+	c_.Call.Do(f)
+	return c_
+}
+
+// Foo(r io.Reader, m missing.Thing) (*missing.Other, []broken.T, error)
+func (r_ *MockFooMockRecorder) Foo(r any, m any) MockFooFooCall {
+	r_.ctrl.T.Helper()
+	call := r_.ctrl.RecordCallWithMethodType(r_.mock(), "Foo", reflect.TypeOf((*MockFoo)(nil).Foo), r, m)
+	return MockFooFooCall{call}
+}
+
+// MockFooFooCall is type safe wrapper of *gomock.Call.
+type MockFooFooCall struct{ *gomock.Call }
+
+// DoAndReturn is type safe wrapper of *gomock.Call DoAndReturn.
+func (c_ MockFooFooCall) DoAndReturn(f func(r io.Reader, m missing.Thing) (*missing.Other, []broken.T, error)) MockFooFooCall {
+	c_.Call.DoAndReturn(f)
+	return c_
+}
+
+// Do is type safe wrapper of *gomock.Call Do.
+func (c_ MockFooFooCall) Do(f func(r io.Reader, m missing.Thing)) MockFooFooCall {
+	c_.Call.Do(f)
+	return c_
+}
+
+// Return is type safe wrapper of *gomock.Call Return.
+func (c_ MockFooFooCall) Return(res0 *missing.Other, res1 []broken.T, res2 error) MockFooFooCall {
+	c_.Call.Return(res0, res1, res2)
+	return c_
+}
+
+func (r_ *MockFooMockRecorder) mock() *MockFoo {
+	return (*MockFoo)(r_)
+}
@@ -0,0 +1,34 @@
+// Code generated by github.com/skipor/gmg - type-safe, fast and handy alternative GoMock generator. DO NOT EDIT.
+// Source: pkg.Foo
+// Method set hash: Foo=b58391ac9b51697e
+// Command: gmg --src pkg --dst ./foo.go --pkg mocks_pkg --template ../counter.tmpl Foo
+// Body hash: 0dc1fb3bb7f0d649
+
+package mocks_pkg
+
+import (
+	io "io"
+	pkg "pkg"
+	atomic "sync/atomic"
+)
+
+// CountingFoo counts Foo method calls.
+//
+// Foo is an example.
+//
+// Multiline doc.
+type CountingFoo struct {
+	Next  pkg.Foo
+	Calls atomic.Int64
+}
+
+// Bar does something.
+func (c_ *CountingFoo) Bar(w io.Writer, as ...int) (n int, err error) {
+	c_.Calls.Add(1)
+	return c_.Next.Bar(w, as...)
+}
+
+func (c_ *CountingFoo) Baz() {
+	c_.Calls.Add(1)
+	c_.Next.Baz()
+}
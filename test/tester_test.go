@@ -1,14 +1,20 @@
 package test
 
 import (
+	"bytes"
+	"context"
 	"go/format"
+	"io"
+	"net"
 	"os/exec"
 	"path/filepath"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/tools/go/packages/packagestest"
@@ -54,67 +60,84 @@ func formatModuleFiles(t *testing.T, m M) {
 }
 
 func (tr *Tester) Gmg(t *testing.T, args ...string) *RunResult {
+	return tr.GmgEnv(t, nil, args...)
+}
+
+// GmgEnv runs gmg with env appended to exported environment.
+// It is useful to emulate 'go generate' call.
+func (tr *Tester) GmgEnv(t *testing.T, env []string, args ...string) *RunResult {
 	args = append(args, "--debug")
 	t.Logf("Run: gmg %s", strings.Join(args, " "))
-	FS := &afero.MemMapFs{}
-	env := &app.Environment{
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	appEnv := &app.Environment{
 		Args:   args,
-		Stderr: testWriter{t},
+		Stdout: stdout,
+		Stderr: io.MultiWriter(testWriter{t}, stderr),
 		Dir:    tr.exported.Config.Dir,
-		Env:    tr.exported.Config.Env,
-		Fs:     FS,
+		Env:    append(append([]string{}, tr.exported.Config.Env...), env...),
+
+		CacheDir:     tr.cacheDir,
+		ServerSocket: tr.serverSocket,
+	}
+	var exitCode int
+	var changed afero.Fs
+	var deleted []string
+	if tr.onDisk {
+		appEnv.Fs = afero.NewOsFs()
+		allowDelete := false
+		for _, arg := range args {
+			allowDelete = allowDelete || arg == "--prune"
+		}
+		changed, deleted = tr.changes(t, "gmg "+strings.Join(args, " "), allowDelete, func() {
+			exitCode = app.Main(appEnv)
+		})
+	} else {
+		// Sources are read from disk, but written files are kept in memory layer, that is result.
+		layer := &afero.MemMapFs{}
+		require.NoError(t, layer.MkdirAll(tr.exported.Config.Dir, 0755))
+		appEnv.Fs = afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(afero.NewOsFs()), layer)
+		exitCode = app.Main(appEnv)
+		changed = afero.NewBasePathFs(layer, tr.exported.Config.Dir)
 	}
-	exitCode := app.Main(env)
 
 	res := &RunResult{
 		t:        t,
 		ExitCode: exitCode,
-		FS:       FS,
+		FS:       changed,
+		Deleted:  deleted,
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
 	}
 	t.Cleanup(func() {
 		if !res.consumed {
 			t.Errorf("no run result assertion called for 'gmg %s'", strings.Join(args, " "))
 		}
+		res.checkDeletedConsumed()
 	})
 	return res
 }
 
-func (tr *Tester) GoGenerate(t *testing.T) *RunResult {
-	t.Helper()
-	PATH := testutil.TestInstallGmgOnce(t)
-
+// changes calls run, and returns files that were created or changed in work dir, and paths of deleted files.
+// Test fails, if files are deleted, but that is not allowed.
+func (tr *Tester) changes(t *testing.T, name string, allowDelete bool, run func()) (afero.Fs, []string) {
 	dir := tr.exported.Config.Dir
-	cmd := exec.Command("go", "generate", "-v", "-x", "./...")
-	w := testWriter{t}
-	cmd.Stdout = w
-	cmd.Stderr = w
-	cmd.Dir = dir
-	cmd.Env = append(tr.exported.Config.Env, "GMG_DEBUG=true", PATH)
-
 	beforeFsMap := fsToMap(t, afero.NewBasePathFs(afero.NewOsFs(), dir))
-
-	t.Logf("Run: %s", cmd.String())
-	err := cmd.Run()
-	var exitCode int
-	if err != nil {
-		err, ok := err.(*exec.ExitError)
-		if !ok {
-			t.Fatalf("Unexpected run fail: %+v", err)
-		}
-		exitCode = err.ExitCode()
-	}
-
-	t.Logf("Workdir tree after '%s':\n%s", cmd.String(), dirTree(t, dir))
-
+	run()
 	afterFsMap := fsToMap(t, afero.NewBasePathFs(afero.NewOsFs(), dir))
 
+	var deleted []string
 	for path := range beforeFsMap {
 		_, ok := afterFsMap[path]
 		if !ok {
-			t.Fatalf("file '%s' removed after '%s' run", path, cmd.String())
+			if !allowDelete {
+				t.Fatalf("file '%s' removed after '%s' run", path, name)
+			}
+			deleted = append(deleted, path)
 		}
 	}
-	changed := afero.NewMemMapFs().(*afero.MemMapFs)
+	sort.Strings(deleted)
+	changed := afero.NewMemMapFs()
 	for path, after := range afterFsMap {
 		before, ok := beforeFsMap[path]
 		if !ok || before != after {
@@ -122,16 +145,46 @@ func (tr *Tester) GoGenerate(t *testing.T) *RunResult {
 			require.NoError(t, err)
 		}
 	}
+	return changed, deleted
+}
+
+func (tr *Tester) GoGenerate(t *testing.T) *RunResult {
+	t.Helper()
+	PATH := testutil.TestInstallGmgOnce(t)
+
+	dir := tr.exported.Config.Dir
+	cmd := exec.Command("go", "generate", "-v", "-x", "./...")
+	w := testWriter{t}
+	cmd.Stdout = w
+	cmd.Stderr = w
+	cmd.Dir = dir
+	cmd.Env = append(tr.exported.Config.Env, "GMG_DEBUG=true", PATH)
+
+	var exitCode int
+	changed, deleted := tr.changes(t, cmd.String(), false, func() {
+		t.Logf("Run: %s", cmd.String())
+		err := cmd.Run()
+		if err != nil {
+			err, ok := err.(*exec.ExitError)
+			if !ok {
+				t.Fatalf("Unexpected run fail: %+v", err)
+			}
+			exitCode = err.ExitCode()
+		}
+		t.Logf("Workdir tree after '%s':\n%s", cmd.String(), dirTree(t, dir))
+	})
 
 	res := &RunResult{
 		t:        t,
 		ExitCode: exitCode,
 		FS:       changed,
+		Deleted:  deleted,
 	}
 	t.Cleanup(func() {
 		if !res.consumed {
 			t.Errorf("no run result assertion called for '%s'", cmd)
 		}
+		res.checkDeletedConsumed()
 	})
 	return res
 }
@@ -141,7 +194,15 @@ type RunResult struct {
 	// consumed set on Succeed or Fail assertion
 	consumed bool
 	ExitCode int
-	FS       *afero.MemMapFs
+	// FS contains files, that were created or changed.
+	FS afero.Fs
+	// Deleted are paths of deleted files.
+	Deleted []string
+	// deletedConsumed set on Deleted assertion
+	deletedConsumed bool
+	// Stdout and Stderr are set only for Gmg run.
+	Stdout string
+	Stderr string
 }
 
 func (r *RunResult) Succeed() *RunResult {
@@ -172,6 +233,39 @@ func (r *RunResult) Files(expectedFiles ...string) *RunResult {
 	return r
 }
 
+func (r *RunResult) DeletedFiles(expectedFiles ...string) *RunResult {
+	r.t.Helper()
+	r.deletedConsumed = true
+	sort.Strings(expectedFiles)
+	diff := cmp.Diff(expectedFiles, r.Deleted, cmpopts.EquateEmpty())
+	if len(diff) > 0 {
+		r.t.Fatalf("Expecated and actual deleted files diff:\n%s", diff)
+	}
+	return r
+}
+
+func (r *RunResult) checkDeletedConsumed() {
+	if len(r.Deleted) != 0 && !r.deletedConsumed {
+		r.t.Errorf("files unexpectedly deleted: %s", r.Deleted)
+	}
+}
+
+func (r *RunResult) StderrContains(substrs ...string) *RunResult {
+	r.t.Helper()
+	for _, s := range substrs {
+		require.Contains(r.t, r.Stderr, s)
+	}
+	return r
+}
+
+func (r *RunResult) StdoutContains(substrs ...string) *RunResult {
+	r.t.Helper()
+	for _, s := range substrs {
+		require.Contains(r.t, r.Stdout, s)
+	}
+	return r
+}
+
 func (r *RunResult) Golden() *RunResult {
 	r.t.Helper()
 	golden.Dir(r.t, r.FS)
@@ -180,6 +274,56 @@ func (r *RunResult) Golden() *RunResult {
 
 type Tester struct {
 	exported *packagestest.Exported
+	// serverSocket is set, when gmg server is started, so Gmg runs are forwarded to it.
+	serverSocket string
+	// cacheDir is set, when generation cache is used by Gmg runs.
+	cacheDir string
+	// onDisk is set, when Gmg runs write to work dir on disk, instead of in-memory layer of every run.
+	onDisk bool
+}
+
+// WriteToDisk makes Gmg runs write files to work dir on disk, so next runs see them, and --prune can delete them.
+// By default, every run writes to its own in-memory layer over disk, so runs are isolated.
+func (tr *Tester) WriteToDisk() {
+	tr.onDisk = true
+}
+
+// UseCache makes Gmg runs use generation cache in temp dir.
+func (tr *Tester) UseCache() {
+	tr.cacheDir = filepath.Join(tr.exported.Temp(), "cache")
+}
+
+// Serve starts gmg server, that is stopped on test cleanup.
+func (tr *Tester) Serve(t *testing.T) {
+	t.Helper()
+	socket := filepath.Join(tr.exported.Temp(), "gmg.sock")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Serve(ctx, &app.Environment{
+			Args:   []string{"--socket", socket, "--debug"},
+			Stderr: testWriter{t},
+			Dir:    tr.exported.Config.Dir,
+			Env:    tr.exported.Config.Env,
+			Fs:     afero.NewOsFs(),
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+	// Not require.Eventually, as its condition goroutine may send on closed channel, when dial is slow.
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		conn, err := net.Dial("unix", socket)
+		if err == nil {
+			_ = conn.Close()
+			break
+		}
+		require.True(t, time.Now().Before(deadline), "server is not started: %s", err)
+	}
+	tr.serverSocket = socket
+	// Server writes files on disk.
+	tr.WriteToDisk()
 }
 
 func export(t *testing.T, modules ...packagestest.Module) *packagestest.Exported {
@@ -0,0 +1,33 @@
+package test
+
+import (
+	"testing"
+)
+
+func TestUnchangedNotWritten(t *testing.T) {
+	tr := newTester(t, M{
+		Name: "pkg",
+		Files: map[string]interface{}{
+			"file.go": /* language=go */ `
+			package pkg
+			type Foo interface { Foo() }
+			type Bar interface { Bar() }
+			`,
+			"mocks/bar.go": /* language=go */ `
+			// Code generated by old gmg version. DO NOT EDIT.
+			package mocks_pkg
+			type MockBar struct{}
+			`,
+		},
+	})
+	tr.WriteToDisk()
+	tr.Gmg(t, "Foo").
+		Succeed().
+		Files("mocks/foo.go").
+		StderrContains("written: mocks/foo.go")
+
+	tr.Gmg(t, "Foo", "Bar").
+		Succeed().
+		Files("mocks/bar.go").
+		StderrContains("unchanged: mocks/foo.go", "written: mocks/bar.go")
+}
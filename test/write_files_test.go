@@ -0,0 +1,90 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skipor/gmg/pkg/gogen"
+)
+
+func TestWriteFiles(t *testing.T) {
+	FS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(FS, "unchanged.go", []byte("package pkg\n\nconst A = 1\n"), 0644))
+	require.NoError(t, afero.WriteFile(FS, "changed.go", []byte("package pkg\n"), 0644))
+
+	g := gogen.NewGenerator()
+	unchanged := g.NewFile("unchanged.go", "pkg").P("package pkg\n\nconst A = 1\n")
+	changed := g.NewFile("changed.go", "pkg").P("package pkg\n\nconst B = 1\n")
+	created := g.NewFile("dir/created.go", "pkg/dir").P("package dir\n")
+	written, err := gogen.WriteFiles(FS, g.Files())
+	require.NoError(t, err)
+	assert.Equal(t, []*gogen.File{changed, created}, written)
+	assert.NotContains(t, written, unchanged)
+	assert.Equal(t, pathToFileContentMap{
+		"unchanged.go":   "package pkg\n\nconst A = 1\n",
+		"changed.go":     "package pkg\n\nconst B = 1\n",
+		"dir/created.go": "package dir\n",
+	}, fsToMap(t, FS))
+}
+
+func TestWriteFiles_Rollback(t *testing.T) {
+	FS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(FS, "changed.go", []byte("package pkg\n"), 0644))
+	before := fsToMap(t, FS)
+
+	g := gogen.NewGenerator()
+	g.NewFile("changed.go", "pkg").P("package pkg\n\nconst B = 1\n")
+	g.NewFile("dir/created.go", "pkg/dir").P("package dir\n")
+	g.NewFile("failed.go", "pkg").P("package pkg\n")
+	err := g.WriteFiles(&failingRenameFs{Fs: FS, path: "failed.go"})
+	require.Error(t, err)
+	assert.Equal(t, before, fsToMap(t, FS))
+	exists, err := afero.DirExists(FS, "dir")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestWriteFiles_KeepMode(t *testing.T) {
+	FS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(FS, "changed.go", []byte("package pkg\n"), 0600))
+
+	g := gogen.NewGenerator()
+	g.NewFile("changed.go", "pkg").P("package pkg\n\nconst B = 1\n")
+	g.NewFile("created.go", "pkg").P("package pkg\n")
+	require.NoError(t, g.WriteFiles(FS))
+	for path, mode := range map[string]os.FileMode{"changed.go": 0600, "created.go": 0644} {
+		info, err := FS.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, mode, info.Mode().Perm(), path)
+	}
+}
+
+func TestWriteFiles_Rollback_EmptyFile(t *testing.T) {
+	FS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(FS, "empty.go", nil, 0644))
+	before := fsToMap(t, FS)
+
+	g := gogen.NewGenerator()
+	g.NewFile("empty.go", "pkg").P("package pkg\n")
+	g.NewFile("failed.go", "pkg").P("package pkg\n")
+	err := g.WriteFiles(&failingRenameFs{Fs: FS, path: "failed.go"})
+	require.Error(t, err)
+	assert.Equal(t, before, fsToMap(t, FS))
+}
+
+type failingRenameFs struct {
+	afero.Fs
+	path string
+}
+
+func (fs *failingRenameFs) Rename(oldname, newname string) error {
+	if newname == fs.path {
+		return errors.New("rename failed")
+	}
+	return fs.Fs.Rename(oldname, newname)
+}